@@ -194,10 +194,28 @@ func (q *AdgangsAdresseQuery) Vejnavn(s ...string) *AdgangsAdresseQuery {
 // Husnr will add a parameter for 'husnr' to the AdgangsAdresseQuery.
 //
 // Husnummer. Max 4 cifre eventuelt med et efterfølgende bogstav. (Flerværdisøgning mulig).
+// Valid husnumre are normalized using ParseHusnr, so "012 b" is sent as "12B".
 //
 // See documentation at http://dawa.aws.dk/adgangsadressedok#adressesoegning
 func (q *AdgangsAdresseQuery) Husnr(s ...string) *AdgangsAdresseQuery {
-	q.add(&textQuery{Name: "husnr", Values: s, Multi: true, Null: false})
+	q.add(&textQuery{Name: "husnr", Values: husnrValues(s), Multi: true, Null: false})
+	return q
+}
+
+// HusnrInterval will add parameters for 'husnrfra' and 'husnrtil' to the AdgangsAdresseQuery.
+//
+// Returnerer kun adresser hvor husnummeret er indenfor intervallet.
+// The side of the interval is not sent to the server, so use i.Contains() on the results
+// to select only even or odd husnumre.
+//
+// See documentation at http://dawa.aws.dk/adgangsadressedok#adressesoegning
+func (q *AdgangsAdresseQuery) HusnrInterval(i HusnrInterval) *AdgangsAdresseQuery {
+	if i.Fra != "" {
+		q.add(&textQuery{Name: "husnrfra", Values: []string{string(i.Fra)}, Multi: false, Null: false})
+	}
+	if i.Til != "" {
+		q.add(&textQuery{Name: "husnrtil", Values: []string{string(i.Til)}, Multi: false, Null: false})
+	}
 	return q
 }
 
@@ -20,10 +20,10 @@ type AdgangsAdresse struct {
 	Ejerlav           Ejerlav             `json:"ejerlav"`           // Det matrikulære ejerlav som adressen ligger i.
 	EsrEjendomsNr     string              `json:"esrejendomsnr"`     // ESR Ejendomsnummer. Indtil 7 cifre.
 	Historik          Historik            `json:"historik"`          // Væsentlige tidspunkter for adgangsadressen
-	Etage             string              `json:"etage"`             // etage
-	Door              string              `json:"dør"`               // dør
+	Etage             Etage               `json:"etage"`             // etage
+	Door              Dør                 `json:"dør"`               // dør
 	Href              string              `json:"href"`              // Adgangsadressens URL.
-	Husnr             string              `json:"husnr"`             // Husnummer. Max 4 cifre eventuelt med et efterfølgende bogstav.
+	Husnr             Husnr               `json:"husnr"`             // Husnummer. Max 4 cifre eventuelt med et efterfølgende bogstav.
 	ID                string              `json:"id"`                // Adgangsadressens unikke id, f.eks. 0a3f5095-45ec-32b8-e044-0003ba298018
 	Kommune           KommuneRef          `json:"kommune"`           // Kommunen som adressen er beliggende i.
 	Kvh               string              `json:"kvh"`               // KVH-nøgle. 12 tegn bestående af 4 cifre der repræsenterer kommunekode, 4 cifre der repræsenterer vejkode efterfulgt af 4 tegn der repræsenter husnr
@@ -34,10 +34,17 @@ type AdgangsAdresse struct {
 	Region            RegionRef           `json:"region"`            // Regionen som adressen er beliggende i. Beregnes udfra adgangspunktet og regionsinddelingerne fra DAGI
 	Retskreds         RetskredsRef        `json:"retskreds"`         // Retskredsen som adressen er beliggende i. Beregnes udfra adgangspunktet og retskredsinddelingerne fra DAGI
 	Sogn              SognRef             `json:"sogn"`              // Sognet som adressen er beliggende i. Beregnes udfra adgangspunktet og sogneinddelingerne fra DAGI
-	Status            int                 `json:"status"`            // Adressens status, som modtaget fra BBR. "1" angiver en endelig adresse og "3" angiver en foreløbig adresse". Adresser med status "2" eller "4" er ikke med i DAWA.
+	Status            Status              `json:"status"`            // Adressens status, som modtaget fra BBR. "1" angiver en endelig adresse og "3" angiver en foreløbig adresse". Adresser med status "2" eller "4" er ikke med i DAWA.
 	SupplerendeBynavn string              `json:"supplerendebynavn"` // Et supplerende bynavn – typisk landsbyens navn – eller andet lokalt stednavn, der er fastsat af kommunen for at præcisere adressens beliggenhed indenfor postnummeret.
 	Vejstykke         VejstykkeRef        `json:"vejstykke"`         // Vejstykket som adressen er knyttet til.
-	Zone              string              `json:"zone"`              // Hvilken zone adressen ligger i. "Byzone", "Sommerhusområde" eller "Landzone". Beregnes udfra adgangspunktet og zoneinddelingerne fra PlansystemDK
+	Zone              Zone                `json:"zone"`              // Hvilken zone adressen ligger i. "Byzone", "Sommerhusområde" eller "Landzone". Beregnes udfra adgangspunktet og zoneinddelingerne fra PlansystemDK
+
+	// Election and statistical areas
+	Storkreds                      StorkredsRef                      `json:"storkreds"`                      // Storkredsen som adressen er beliggende i. Beregnes udfra adgangspunktet og opstillingskredsinddelingerne fra DAGI
+	Valglandsdel                   ValglandsdelRef                   `json:"valglandsdel"`                   // Valglandsdelen som adressen er beliggende i. Beregnes udfra adgangspunktet og opstillingskredsinddelingerne fra DAGI
+	Landsdel                       LandsdelRef                       `json:"landsdel"`                       // Landsdelen (NUTS 3) som adressen er beliggende i. Beregnes udfra adgangspunktet og landsdelsinddelingerne fra DAGI
+	Afstemningsområde              AfstemningsområdeRef              `json:"afstemningsområde"`              // Afstemningsområdet som adressen er beliggende i. Beregnes udfra adgangspunktet og afstemningsområdeinddelingerne fra DAGI
+	Menighedsrådsafstemningsområde MenighedsrådsafstemningsområdeRef `json:"menighedsrådsafstemningsområde"` // Menighedsrådsafstemningsområdet som adressen er beliggende i. Beregnes udfra adgangspunktet og menighedsrådsafstemningsområdeinddelingerne fra DAGI
 
 	// Fields returned in autocomplete
 	Text                string `json:"tekst"`
@@ -48,7 +55,7 @@ type AdgangsAdresse struct {
 type AutocompleteAddress struct {
 	ID         string  `json:"id"`
 	Street     string  `json:"vejnavn"`
-	Husnr      string  `json:"husnr"`
+	Husnr      Husnr   `json:"husnr"`
 	PostNumber string  `json:"postnr"`
 	PostName   string  `json:"postnrnavn"`
 	Floor      *string `json:"etage"`
@@ -70,12 +77,13 @@ type ChangeInfo struct {
 
 // Geografisk punkt, som angiver særskilt adgang fra navngiven vej ind på et areal eller bygning.
 type Adgangspunkt struct {
-	Kilde           int       `json:"kilde"`           // Kode der angiver kilden til adressepunktet. Et tegn. ”1” = oprettet maskinelt fra teknisk kort; ”2” = Oprettet maskinelt fra af matrikelnummer tyngdepunkt; ”3” = Eksternt indberettet af konsulent på vegne af kommunen; ”4” = Eksternt indberettet af kommunes kortkontor o.l. ”5” = Oprettet af teknisk forvaltning."
-	Koordinater     []float64 `json:"koordinater"`     // Adgangspunktets koordinater som array [x,y].  *sic*
-	Nøjagtighed     string    `json:"nøjagtighed"`     // Kode der angiver nøjagtigheden for adressepunktet. Et tegn. ”A” betyder at adressepunktet er absolut placeret på et detaljeret grundkort, tyisk med en nøjagtighed bedre end +/- 2 meter. ”B” betyder at adressepunktet er beregnet – typisk på basis af matrikelkortet, således at adressen ligger midt på det pågældende matrikelnummer. I så fald kan nøjagtigheden være ringere en end +/- 100 meter afhængig af forholdene. ”U” betyder intet adressepunkt.
-	Tekniskstandard string    `json:"tekniskstandard"` // Kode der angiver den specifikation adressepunktet skal opfylde. 2 tegn. ”TD” = 3 meter inde i bygningen ved det sted hvor indgangsdør e.l. skønnes placeret; ”TK” = Udtrykkelig TK-standard: 3 meter inde i bygning, midt for længste side mod vej; ”TN” Alm. teknisk standard: bygningstyngdepunkt eller blot i bygning; ”UF” = Uspecificeret/foreløbig: ikke nødvendigvis placeret i bygning."
-	Tekstretning    float64   `json:"tekstretning"`    // Angiver en evt. retningsvinkel for adressen i ”gon” dvs. hvor hele cirklen er 400 gon og 200 er vandret. Værdier 0.00-400.00: Eksempel: ”128.34”.
-	Ændret          AwsTime   `json:"ændret"`          // Dato for sidste ændring i adressepunktet, som registreret af BBR.
+	Kilde           Kilde           `json:"kilde"`           // Kode der angiver kilden til adressepunktet. Et tegn. ”1” = oprettet maskinelt fra teknisk kort; ”2” = Oprettet maskinelt fra af matrikelnummer tyngdepunkt; ”3” = Eksternt indberettet af konsulent på vegne af kommunen; ”4” = Eksternt indberettet af kommunes kortkontor o.l. ”5” = Oprettet af teknisk forvaltning."
+	Koordinater     []float64       `json:"koordinater"`     // Adgangspunktets koordinater som array [x,y].  *sic*
+	Nøjagtighed     Nøjagtighed     `json:"nøjagtighed"`     // Kode der angiver nøjagtigheden for adressepunktet. Et tegn. ”A” betyder at adressepunktet er absolut placeret på et detaljeret grundkort, tyisk med en nøjagtighed bedre end +/- 2 meter. ”B” betyder at adressepunktet er beregnet – typisk på basis af matrikelkortet, således at adressen ligger midt på det pågældende matrikelnummer. I så fald kan nøjagtigheden være ringere en end +/- 100 meter afhængig af forholdene. ”U” betyder intet adressepunkt.
+	Tekniskstandard Tekniskstandard `json:"tekniskstandard"` // Kode der angiver den specifikation adressepunktet skal opfylde. 2 tegn. ”TD” = 3 meter inde i bygningen ved det sted hvor indgangsdør e.l. skønnes placeret; ”TK” = Udtrykkelig TK-standard: 3 meter inde i bygning, midt for længste side mod vej; ”TN” Alm. teknisk standard: bygningstyngdepunkt eller blot i bygning; ”UF” = Uspecificeret/foreløbig: ikke nødvendigvis placeret i bygning."
+	Tekstretning    Tekstretning    `json:"tekstretning"`    // Angiver en evt. retningsvinkel for adressen i ”gon” dvs. hvor hele cirklen er 400 gon og 200 er vandret. Værdier 0.00-400.00: Eksempel: ”128.34”.
+	Ændret          AwsTime         `json:"ændret"`          // Dato for sidste ændring i adressepunktet, som registreret af BBR.
+	Højde           *float64        `json:"højde"`           // Terrænhøjden i meter over havets overflade (DVR90) ved adgangspunktet. nil hvis højden ikke er kendt.
 }
 
 type Ejerlav struct {
@@ -163,11 +171,74 @@ type Sogn struct {
 	ChangeInfo
 }
 
+type ValglandsdelRef struct {
+	Href    string `json:"href"`    // Valglandsdelens unikke URL
+	Bogstav string `json:"bogstav"` // Valglandsdelens bogstav, f.eks. "A"
+	Navn    string `json:"navn"`    // Valglandsdelens navn
+}
+
 type Valglandsdel struct {
-	Bogstav string `json:"bogstav"`
-	Href    string `json:"href"`
-	Navn    string `json:"navn"`
+	ValglandsdelRef
+	ChangeInfo
+}
+
+type StorkredsRef struct {
+	Href   string `json:"href"`   // Storkredsens unikke URL
+	Nummer string `json:"nummer"` // Storkredsens nummer
+	Navn   string `json:"navn"`   // Storkredsens navn
+}
+
+type Storkreds struct {
+	StorkredsRef
+	ChangeInfo
+	Regionskode  string          `json:"regionskode"`  // Regionskode for den region storkredsen er beliggende i. 4 cifre.
+	Valglandsdel ValglandsdelRef `json:"valglandsdel"` // Valglandsdelen som storkredsen tilhører.
+}
+
+type LandsdelRef struct {
+	Href  string `json:"href"`  // Landsdelens unikke URL
+	Nuts3 string `json:"nuts3"` // Landsdelens NUTS 3 kode, f.eks. "DK011"
+	Navn  string `json:"navn"`  // Landsdelens navn
+}
+
+type Landsdel struct {
+	LandsdelRef
+	ChangeInfo
+}
+
+type AfstemningsområdeRef struct {
+	Href   string `json:"href"`   // Afstemningsområdets unikke URL
+	Nummer string `json:"nummer"` // Afstemningsområdets nummer. Unikt indenfor kommunen.
+	Navn   string `json:"navn"`   // Afstemningsområdets navn
+}
+
+// Afstemningssted angiver hvor der stemmes for et afstemningsområde.
+type Afstemningssted struct {
+	Navn           string            `json:"navn"`           // Afstemningsstedets navn
+	Adgangsadresse AdgangsAdresseRef `json:"adgangsadresse"` // Afstemningsstedets adgangsadresse
+}
+
+type Afstemningsområde struct {
+	AfstemningsområdeRef
 	ChangeInfo
+	DagiID           string              `json:"dagi_id"`          // Afstemningsområdets id i DAGI
+	Afstemningssted  Afstemningssted     `json:"afstemningssted"`  // Afstemningsstedet for afstemningsområdet
+	Kommune          KommuneRef          `json:"kommune"`          // Kommunen som afstemningsområdet er beliggende i
+	Opstillingskreds OpstillingskredsRef `json:"opstillingskreds"` // Opstillingskredsen som afstemningsområdet er beliggende i
+}
+
+type MenighedsrådsafstemningsområdeRef struct {
+	Href   string `json:"href"`   // Menighedsrådsafstemningsområdets unikke URL
+	Nummer string `json:"nummer"` // Menighedsrådsafstemningsområdets nummer. Unikt indenfor kommunen.
+	Navn   string `json:"navn"`   // Menighedsrådsafstemningsområdets navn
+}
+
+type Menighedsrådsafstemningsområde struct {
+	MenighedsrådsafstemningsområdeRef
+	ChangeInfo
+	DagiID  string     `json:"dagi_id"` // Menighedsrådsafstemningsområdets id i DAGI
+	Kommune KommuneRef `json:"kommune"` // Kommunen som menighedsrådsafstemningsområdet er beliggende i
+	Sogn    SognRef    `json:"sogn"`    // Sognet som menighedsrådsafstemningsområdet hører til
 }
 
 type VejstykkeRef struct {
@@ -188,10 +259,18 @@ func (a AdgangsAdresseRef) Get() (*AdgangsAdresse, error) {
 	return GetAAID(a.ID)
 }
 
+// Jordstykke will return the jordstykke (cadastral parcel) the address is placed on.
+// Uses the Ejerlav.Kode and Matrikelnr fields.
+// Will return (nil, io.EOF) if the jordstykke cannot be found.
+func (a AdgangsAdresse) Jordstykke() (*Jordstykke, error) {
+	return GetJordstykke(a.Ejerlav.Kode, a.Matrikelnr)
+}
+
 // AdgangsAdresse is an Iterator that enable you to get individual entries.
 type AdgangsAdresseIter struct {
 	a   chan AdgangsAdresse
 	err error
+	validating
 	closer
 }
 
@@ -199,15 +278,29 @@ type AdgangsAdresseIter struct {
 // It will return an error if that has been encountered.
 // When there are not more entries nil, io.EOF will be returned.
 func (a *AdgangsAdresseIter) Next() (*AdgangsAdresse, error) {
-	v, ok := <-a.a
-	if ok {
-		return &v, nil
+	for {
+		v, ok := <-a.a
+		if !ok {
+			return nil, a.err
+		}
+		if !a.skip(&v) {
+			return &v, nil
+		}
 	}
-	return nil, a.err
 }
 
-// ImportAdresserCSV will import "adresser" from a CSV file, supplied to the reader.
+// ValidateTo will make the iterator validate each record with its Validate method.
+// Invalid records are sent to the sink and skipped, instead of aborting the import.
+// It must be called before the first call to Next.
+func (a *AdgangsAdresseIter) ValidateTo(sink ErrorSink) *AdgangsAdresseIter {
+	a.sink = sink
+	return a
+}
+
+// ImportAdgangsAdresserCSV will import "adgangsadresser" from a CSV file, supplied to the reader.
 // An iterator will be returned that return all addresses.
+// Unknown status, kilde, nøjagtighed, tekniskstandard and zone codes are kept in the records,
+// so they are reported by Validate and can be sent to the sink given to ValidateTo.
 func ImportAdgangsAdresserCSV(in io.Reader) (*AdgangsAdresseIter, error) {
 	r := csv.NewReader(in)
 	r.Comma = ','
@@ -238,11 +331,7 @@ func ImportAdgangsAdresserCSV(in io.Reader) (*AdgangsAdresseIter, error) {
 			// PROCESS: id,status,oprettet,ændret,vejkode,vejnavn,husnr,etage,dør,supplerendebynavn
 			a := AdgangsAdresse{}
 			a.ID = v["id"]
-			a.Status, err = strconv.Atoi(v["status"])
-			if err != nil {
-				ret.err = err
-				return
-			}
+			a.Status = statusValue(v["status"])
 
 			// Example 2000-02-16T21:58:33.000
 			o, err := ParseTime(v["oprettet"])
@@ -261,9 +350,9 @@ func ImportAdgangsAdresserCSV(in io.Reader) (*AdgangsAdresseIter, error) {
 
 			a.Vejstykke.Kode = v["vejkode"]
 			a.Vejstykke.Navn = v["vejnavn"]
-			a.Husnr = v["husnr"]
-			a.Etage = v["etage"]
-			a.Door = v["dør"]
+			a.Husnr = Husnr(v["husnr"])
+			a.Etage = etageValue(v["etage"])
+			a.Door = dørValue(v["dør"])
 			a.SupplerendeBynavn = v["supplerendebynavn"]
 			a.Postnummer.Nr = v["postnr"]
 			a.Postnummer.Navn = v["postnrnavn"]
@@ -280,10 +369,11 @@ func ImportAdgangsAdresserCSV(in io.Reader) (*AdgangsAdresseIter, error) {
 			a.Adgangspunkt.Koordinater[0], _ = strconv.ParseFloat(v["wgs84koordinat_bredde"], 64)
 			a.Adgangspunkt.Koordinater[1], _ = strconv.ParseFloat(v["wgs84koordinat_længde"], 64)
 
-			a.Adgangspunkt.Nøjagtighed = v["nøjagtighed"]
-			a.Adgangspunkt.Kilde, _ = strconv.Atoi(v["kilde"])
-			a.Adgangspunkt.Tekniskstandard = v["tekniskstandard"]
-			a.Adgangspunkt.Tekstretning, _ = strconv.ParseFloat(v["tekstretning"], 64)
+			a.Adgangspunkt.Nøjagtighed = nøjagtighedValue(v["nøjagtighed"])
+			a.Adgangspunkt.Kilde = kildeValue(v["kilde"])
+			a.Adgangspunkt.Tekniskstandard = tekniskstandardValue(v["tekniskstandard"])
+			tekstretning, _ := strconv.ParseFloat(v["tekstretning"], 64)
+			a.Adgangspunkt.Tekstretning = Tekstretning(tekstretning)
 			a.DDKN.M100 = v["ddkn_m100"]
 			a.DDKN.Km1 = v["ddkn_km1"]
 			a.DDKN.Km10 = v["ddkn_km10"]
@@ -293,6 +383,9 @@ func ImportAdgangsAdresserCSV(in io.Reader) (*AdgangsAdresseIter, error) {
 				return
 			}
 			a.Adgangspunkt.Ændret = *o
+			if h, err := strconv.ParseFloat(v["højde"], 64); err == nil {
+				a.Adgangspunkt.Højde = &h
+			}
 			a.Region.Kode = v["regionskode"]
 			a.Region.Navn = v["regionsnavn"]
 			a.Sogn.Kode = v["sognekode"]
@@ -305,7 +398,20 @@ func ImportAdgangsAdresserCSV(in io.Reader) (*AdgangsAdresseIter, error) {
 			// opstilli	ngskredskode,opstillingskredsnavn,zone
 			a.Opstillingskreds.Kode = v["opstillingskredskode"]
 			a.Opstillingskreds.Navn = v["opstillingskredsnavn"]
-			a.Zone = v["zone"]
+			a.Zone = zoneValue(v["zone"])
+
+			// storkredsnummer,storkredsnavn,valglandsdelsbogstav,valglandsdelsnavn,landsdelsnuts3,landsdelsnavn,
+			// afstemningsområdenummer,afstemningsområdenavn,menighedsrådsafstemningsområdenummer,menighedsrådsafstemningsområdenavn
+			a.Storkreds.Nummer = v["storkredsnummer"]
+			a.Storkreds.Navn = v["storkredsnavn"]
+			a.Valglandsdel.Bogstav = v["valglandsdelsbogstav"]
+			a.Valglandsdel.Navn = v["valglandsdelsnavn"]
+			a.Landsdel.Nuts3 = v["landsdelsnuts3"]
+			a.Landsdel.Navn = v["landsdelsnavn"]
+			a.Afstemningsområde.Nummer = v["afstemningsområdenummer"]
+			a.Afstemningsområde.Navn = v["afstemningsområdenavn"]
+			a.Menighedsrådsafstemningsområde.Nummer = v["menighedsrådsafstemningsområdenummer"]
+			a.Menighedsrådsafstemningsområde.Navn = v["menighedsrådsafstemningsområdenavn"]
 			ret.a <- a
 		}
 	}()
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"reflect"
+	"strings"
 	"testing"
 	//"github.com/gobs/pretty"
 )
@@ -125,6 +126,42 @@ func TestImportAdgangsAdresserCSV(t *testing.T) {
 	}
 }
 
+func TestImportAdgangsAdresserCSVInvalidCode(t *testing.T) {
+	for _, r := range []struct{ old, new, field string }{
+		{",Byzone", ",Månezone", "Zone"},
+		{",A,5,TD,", ",Q,5,TD,", "Adgangspunkt.Nøjagtighed"},
+		{",A,5,TD,", ",A,17,TD,", "Adgangspunkt.Kilde"},
+		{",A,5,TD,", ",A,x,TD,", "Adgangspunkt.Kilde"},
+	} {
+		b := bytes.NewBufferString(strings.Replace(adgangs_csv_data, r.old, r.new, 1))
+		iter, err := ImportAdgangsAdresserCSV(b)
+		if err != nil {
+			t.Fatalf("ImportAdgangsAdresserCSV: %v", err)
+		}
+		// The invalid record is sent to the sink, and the import continues.
+		var invalid []string
+		iter.ValidateTo(func(rec interface{}, err error) {
+			for _, f := range err.(ValidationError) {
+				invalid = append(invalid, f.Field)
+			}
+		})
+		n := 0
+		for {
+			_, err := iter.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s: %v", r.new, err)
+			}
+			n++
+		}
+		if n != 2 || len(invalid) != 1 || invalid[0] != r.field {
+			t.Fatalf("%s: got %d valid records and violations %v", r.new, n, invalid)
+		}
+	}
+}
+
 var adgangs_json_input = `[
 {
   "href": "http://dawa.aws.dk/adgangsadresser/0a3f507a-3669-32b8-e044-0003ba298018",
@@ -462,3 +499,35 @@ func TestImportAdgangsAdresserJSON(t *testing.T) {
 		t.Fatalf("ImportAdgangsAdresserJSON: Expected io.EOF, got:%v", err)
 	}
 }
+
+func TestImportAdgangsAdresserJSONAreaRefs(t *testing.T) {
+	input := `[{"id":"0a3f507a-3669-32b8-e044-0003ba298018",
+	"storkreds":{"href":"http://dawa.aws.dk/storkredse/1","nummer":"1","navn":"København"},
+	"valglandsdel":{"href":"http://dawa.aws.dk/valglandsdele/A","bogstav":"A","navn":"Hovedstaden"},
+	"landsdel":{"href":"http://dawa.aws.dk/landsdele/DK011","nuts3":"DK011","navn":"Byen København"},
+	"afstemningsområde":{"href":"http://dawa.aws.dk/afstemningsomraader/101/8","nummer":"8","navn":"8. Vesterbro"},
+	"menighedsrådsafstemningsområde":{"href":"http://dawa.aws.dk/menighedsraadsafstemningsomraader/101/4","nummer":"4","navn":"Vesterbro"}}]`
+	iter, err := ImportAdgangsAdresserJSON(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := iter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Storkreds != (StorkredsRef{Href: "http://dawa.aws.dk/storkredse/1", Nummer: "1", Navn: "København"}) {
+		t.Fatalf("unexpected storkreds: %#v", a.Storkreds)
+	}
+	if a.Valglandsdel.Bogstav != "A" {
+		t.Fatalf("unexpected valglandsdel: %#v", a.Valglandsdel)
+	}
+	if a.Landsdel.Nuts3 != "DK011" {
+		t.Fatalf("unexpected landsdel: %#v", a.Landsdel)
+	}
+	if a.Afstemningsområde.Nummer != "8" || a.Afstemningsområde.Navn != "8. Vesterbro" {
+		t.Fatalf("unexpected afstemningsområde: %#v", a.Afstemningsområde)
+	}
+	if a.Menighedsrådsafstemningsområde.Nummer != "4" {
+		t.Fatalf("unexpected menighedsrådsafstemningsområde: %#v", a.Menighedsrådsafstemningsområde)
+	}
+}
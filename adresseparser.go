@@ -0,0 +1,390 @@
+package dawa
+
+import (
+	"io"
+	"strings"
+	"unicode"
+)
+
+// ParsedAdresse is a free-text adresse split into its parts by an AdresseParser.
+// Parts that were not found are empty.
+type ParsedAdresse struct {
+	Vejnavn           string // Vejnavn. Corrected if the parser has a VejnavnMatcher.
+	Husnr             Husnr  // Husnummer, normalized.
+	Etage             Etage  // Etagebetegnelse, normalized.
+	Dør               Dør    // Dørbetegnelse, normalized.
+	SupplerendeBynavn string // Supplerende bynavn.
+	Postnr            string // Postnummer. 4 cifre.
+	Postnrnavn        string // Postnummerets navn. Corrected if the parser knows the postnummer.
+	Ukendt            string // Text that could not be interpreted, like a name line.
+
+	// Score between 0 and 1 indicating how confident the parser is in the result.
+	// Missing parts, corrections and text that could not be interpreted lower the score.
+	// The score is 0 if neither a vejnavn nor a postnummer was found.
+	Score float64
+}
+
+// Query returns an AdresseQuery with the parts that were found as filters.
+// Parts that were not found are not added, so they don't restrict the result.
+func (p ParsedAdresse) Query() *AdresseQuery {
+	q := NewAdresseQuery()
+	if p.Vejnavn != "" {
+		q.Vejnavn(p.Vejnavn)
+	}
+	if p.Husnr != "" {
+		q.Husnr(string(p.Husnr))
+	}
+	if p.Etage != "" {
+		q.Etage(string(p.Etage))
+	}
+	if p.Dør != "" {
+		q.Dør(string(p.Dør))
+	}
+	if p.SupplerendeBynavn != "" {
+		q.SupplerendeBynavn(p.SupplerendeBynavn)
+	}
+	if p.Postnr != "" {
+		q.Postnr(p.Postnr)
+	}
+	return q
+}
+
+// AdresseParser splits free-text Danish adresser, like "Rødkildevej 46, 1. tv, 2400 København NV",
+// into vejnavn, husnr, etage, dør, supplerende bynavn, postnr and postnrnavn,
+// without contacting the server.
+//
+// The parts may be separated by commas or only by spaces, and the postnummer line may be placed first.
+// Common typos, like missing spaces in "Rødkildevej46" and "1.tv", are handled.
+//
+// If Vejnavne is set, the vejnavn is corrected to the best match.
+// If postnumre are added, the postnrnavn is corrected, and a wrong postnr is corrected if the postnrnavn is unique.
+//
+// The parser is not safe for concurrent modification, but
+// parsing can be done concurrently when it is no longer modified.
+//
+// Use NewAdresseParser() to get an initialized object.
+// Example:
+//			p := dawa.NewAdresseParser()
+//			err := p.LoadPostnumre(iter)
+//			a := p.Parse("Rødkildevej 46, 1. tv, 2400 København NV")
+//			q := a.Query()
+type AdresseParser struct {
+	Vejnavne *VejnavnMatcher // Optional matcher used to correct vejnavne.
+
+	postnumre map[string]string   // nr -> navn
+	navne     map[string][]string // normalized navn -> nr
+}
+
+// NewAdresseParser returns a new AdresseParser without known vejnavne and postnumre.
+func NewAdresseParser() *AdresseParser {
+	return &AdresseParser{postnumre: make(map[string]string), navne: make(map[string][]string)}
+}
+
+// ParseAdresse will parse a free-text adresse without known vejnavne and postnumre.
+// See AdresseParser for details.
+func ParseAdresse(s string) ParsedAdresse {
+	return NewAdresseParser().Parse(s)
+}
+
+// AddPostnummer will add a known postnummer.
+func (p *AdresseParser) AddPostnummer(nr, navn string) {
+	if _, ok := p.postnumre[nr]; ok {
+		return
+	}
+	p.postnumre[nr] = navn
+	n := NormalizeVejnavn(navn)
+	p.navne[n] = append(p.navne[n], nr)
+}
+
+// LoadPostnumre will add all postnumre from the iterator.
+// The iterator is read until io.EOF is returned.
+func (p *AdresseParser) LoadPostnumre(iter *PostnummerIter) error {
+	for {
+		v, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		p.AddPostnummer(v.Nr, v.Navn)
+	}
+}
+
+// Parse will split the free-text adresse into its parts.
+func (p *AdresseParser) Parse(s string) ParsedAdresse {
+	res := ParsedAdresse{Score: 1}
+	var ukendt []string
+	segs := adresseSegments(s)
+
+	// Find the postnummer, preferably followed by the postnrnavn.
+	pi, pj, best := -1, -1, 0
+	for i, seg := range segs {
+		for j, t := range seg {
+			if !adresseIsPostnr(t) {
+				continue
+			}
+			q := 1
+			if j+1 < len(seg) && !adresseHasDigit(seg[j+1]) {
+				q = 2
+			}
+			if q >= best {
+				pi, pj, best = i, j, q
+			}
+		}
+	}
+	street := segs
+	if pi >= 0 {
+		seg := segs[pi]
+		res.Postnr = adressePostnr(seg[pj])
+		res.Postnrnavn = strings.Join(seg[pj+1:], " ")
+		street = make([][]string, 0, len(segs))
+		street = append(street, segs[:pi]...)
+		if pj > 0 {
+			street = append(street, seg[:pj])
+		}
+		if pi == 0 && pj == 0 && len(segs) > 1 {
+			// Postnummer line placed first.
+			res.Score -= 0.05
+		}
+		street = append(street, segs[pi+1:]...)
+		p.correctPostnr(&res)
+	} else {
+		res.Score -= 0.2
+	}
+
+	// Find the segment with vejnavn and husnr.
+	si, hi := -1, -1
+	for i, seg := range street {
+		for j := 1; j < len(seg); j++ {
+			if adresseIsHusnr(seg[j]) {
+				si, hi = i, j
+				break
+			}
+		}
+		if si >= 0 {
+			break
+		}
+	}
+	var rest [][]string
+	switch {
+	case si >= 0:
+		for _, seg := range street[:si] {
+			ukendt = append(ukendt, strings.Join(seg, " "))
+		}
+		seg := street[si]
+		res.Vejnavn = strings.Join(seg[:hi], " ")
+		h := seg[hi]
+		next := hi + 1
+		if next < len(seg) && adresseIsHusnrBogstav(seg[next]) {
+			h += seg[next]
+			next++
+		}
+		res.Husnr, _ = ParseHusnr(h)
+		rest = append([][]string{seg[next:]}, street[si+1:]...)
+	case len(street) > 0 && adresseHasLetter(strings.Join(street[0], "")):
+		res.Vejnavn = strings.Join(street[0], " ")
+		rest = street[1:]
+		res.Score -= 0.3
+	default:
+		// A vejnavn must contain letters, so the text cannot be interpreted.
+		for _, seg := range street {
+			ukendt = append(ukendt, strings.Join(seg, " "))
+		}
+	}
+	if res.Vejnavn == "" {
+		res.Score -= 0.5
+	}
+
+	// Etage, dør and supplerende bynavn follow the husnr.
+	for i, seg := range rest {
+		e, d, words := adresseEtageDør(seg)
+		if i > 0 && len(words) > 0 {
+			// A separate segment with words is a bynavn, like "St. Heddinge".
+			e, d, words = "", "", seg
+		}
+		if (e != "" && res.Etage != "") || (d != "" && res.Dør != "") {
+			words = seg
+		} else {
+			if e != "" {
+				res.Etage = e
+			}
+			if d != "" {
+				res.Dør = d
+			}
+		}
+		if len(words) == 0 {
+			continue
+		}
+		if res.SupplerendeBynavn == "" && !adresseHasDigit(strings.Join(words, "")) {
+			res.SupplerendeBynavn = strings.Join(words, " ")
+			continue
+		}
+		ukendt = append(ukendt, strings.Join(words, " "))
+	}
+
+	if res.Vejnavn != "" && p.Vejnavne != nil {
+		if m, ok := p.Vejnavne.Best(res.Vejnavn); ok {
+			res.Vejnavn = m.Navn
+			res.Score *= m.Score
+		} else {
+			res.Score -= 0.3
+		}
+	}
+	res.Ukendt = strings.Join(ukendt, ", ")
+	res.Score -= 0.1 * float64(len(ukendt))
+	if res.Score < 0 || (res.Vejnavn == "" && res.Postnr == "") {
+		// Nothing that identifies an adresse was found.
+		res.Score = 0
+	}
+	return res
+}
+
+// correctPostnr will correct the postnrnavn, or the postnr if the postnrnavn is unique,
+// using the known postnumre.
+func (p *AdresseParser) correctPostnr(res *ParsedAdresse) {
+	if len(p.postnumre) == 0 {
+		return
+	}
+	norm := NormalizeVejnavn(res.Postnrnavn)
+	if navn, ok := p.postnumre[res.Postnr]; ok {
+		if norm != "" && norm != NormalizeVejnavn(navn) {
+			res.Score -= 0.1
+		}
+		res.Postnrnavn = navn
+		return
+	}
+	if nr := p.navne[norm]; len(nr) == 1 {
+		res.Postnr, res.Postnrnavn = nr[0], p.postnumre[nr[0]]
+		res.Score -= 0.1
+		return
+	}
+	res.Score -= 0.2
+}
+
+// adresseEtageDør returns the etage and dør at the start of the segment,
+// and the remaining words.
+func adresseEtageDør(seg []string) (e Etage, d Dør, words []string) {
+	for i, t := range seg {
+		switch {
+		case strings.EqualFold(strings.TrimSuffix(t, "."), "sal") && e != "" && d == "":
+		case e == "" && d == "" && adresseIsEtage(t):
+			e, _ = ParseEtage(t)
+		case d == "" && adresseIsDør(t):
+			d, _ = ParseDør(t)
+		default:
+			return e, d, seg[i:]
+		}
+	}
+	return e, d, nil
+}
+
+// adresseSegments splits s into comma separated segments of tokens.
+// Etage and dør are only split after the first husnr, so vejnavne like
+// "Christian 10.s Gade" are kept.
+func adresseSegments(s string) [][]string {
+	var res [][]string
+	husnr := false
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		var seg []string
+		for _, f := range strings.Fields(part) {
+			for _, t := range adresseSplitToken(f, husnr) {
+				if len(seg) > 0 && adresseIsHusnr(t) {
+					husnr = true
+				}
+				seg = append(seg, t)
+			}
+		}
+		if len(seg) > 0 {
+			res = append(res, seg)
+		}
+	}
+	return res
+}
+
+// adresseSplitToken splits tokens with missing spaces,
+// like "1.tv", "Rødkildevej46" and "2400København".
+// Dots are only split after an etage followed by a dør, and only if etageDør is true,
+// so names like "H.C. Andersens Boulevard" are kept.
+func adresseSplitToken(t string, etageDør bool) []string {
+	r := []rune(t)
+	for i := 1; i < len(r); i++ {
+		prev, cur := r[i-1], r[i]
+		switch {
+		case etageDør && prev == '.' && cur != '.' && adresseIsEtage(string(r[:i])) && adresseIsDør(string(r[i:])):
+		case unicode.IsLetter(prev) && unicode.IsDigit(cur) && i >= 3 && !strings.EqualFold(string(r[:i]), "kl"):
+		case unicode.IsDigit(prev) && unicode.IsLetter(cur) && i == 4 && adresseIsPostnr(string(r[:i])):
+		default:
+			continue
+		}
+		return append([]string{string(r[:i])}, adresseSplitToken(string(r[i:]), etageDør)...)
+	}
+	return []string{t}
+}
+
+// adresseIsPostnr returns true if t is a 4 digit postnummer, optionally prefixed by "DK-".
+func adresseIsPostnr(t string) bool {
+	t = adressePostnr(t)
+	if len(t) != 4 {
+		return false
+	}
+	for _, r := range t {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func adressePostnr(t string) string {
+	if len(t) > 3 && strings.EqualFold(t[:3], "dk-") {
+		return t[3:]
+	}
+	return t
+}
+
+// adresseIsHusnr returns true if t is a husnummer.
+// Numbers followed by a dot, like in "6. Julivej", are not.
+func adresseIsHusnr(t string) bool {
+	if strings.HasSuffix(t, ".") {
+		return false
+	}
+	_, err := ParseHusnr(t)
+	return err == nil
+}
+
+// adresseIsHusnrBogstav returns true if t is a single letter that may be part of a husnummer, like "B" in "46 B".
+func adresseIsHusnrBogstav(t string) bool {
+	return len(t) == 1 && unicode.IsLetter(rune(t[0]))
+}
+
+func adresseIsEtage(t string) bool {
+	_, err := ParseEtage(t)
+	return err == nil
+}
+
+// adresseIsDør returns true if t looks like a dørbetegnelse.
+// Words are only accepted if they are "tv", "mf", "th" or a single letter,
+// since short words are more likely part of a bynavn.
+func adresseIsDør(t string) bool {
+	d, err := ParseDør(t)
+	if err != nil {
+		return false
+	}
+	switch d {
+	case DørTv, DørMf, DørTh:
+		return true
+	}
+	if len([]rune(string(d))) == 1 || adresseHasDigit(string(d)) {
+		return true
+	}
+	return strings.ContainsAny(string(d), "/-")
+}
+
+func adresseHasDigit(s string) bool {
+	return strings.IndexFunc(s, unicode.IsDigit) >= 0
+}
+
+func adresseHasLetter(s string) bool {
+	return strings.IndexFunc(s, unicode.IsLetter) >= 0
+}
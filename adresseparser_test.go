@@ -0,0 +1,90 @@
+package dawa
+
+import (
+	"testing"
+)
+
+func TestParseAdresse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ParsedAdresse
+	}{
+		{"Rødkildevej 46, 1. tv, 2400 København NV",
+			ParsedAdresse{Vejnavn: "Rødkildevej", Husnr: "46", Etage: "1", Dør: "tv", Postnr: "2400", Postnrnavn: "København NV"}},
+		{"Rødkildevej 46 1 tv 2400 København NV",
+			ParsedAdresse{Vejnavn: "Rødkildevej", Husnr: "46", Etage: "1", Dør: "tv", Postnr: "2400", Postnrnavn: "København NV"}},
+		{"rødkildevej46, 1.tv,2400københavn nv",
+			ParsedAdresse{Vejnavn: "rødkildevej", Husnr: "46", Etage: "1", Dør: "tv", Postnr: "2400", Postnrnavn: "københavn nv"}},
+		{"2400 København NV, Rødkildevej 46 B, st. th.",
+			ParsedAdresse{Vejnavn: "Rødkildevej", Husnr: "46B", Etage: "st", Dør: "th", Postnr: "2400", Postnrnavn: "København NV"}},
+		{"Ådalen 4, Øster Assels, 7990 Øster Assels",
+			ParsedAdresse{Vejnavn: "Ådalen", Husnr: "4", SupplerendeBynavn: "Øster Assels", Postnr: "7990", Postnrnavn: "Øster Assels"}},
+		{"Vestergade 2, St. Heddinge, DK-4660 Store Heddinge",
+			ParsedAdresse{Vejnavn: "Vestergade", Husnr: "2", SupplerendeBynavn: "St. Heddinge", Postnr: "4660", Postnrnavn: "Store Heddinge"}},
+		{"Gl. Kongevej 10, 3. sal 12, 1610 København V",
+			ParsedAdresse{Vejnavn: "Gl. Kongevej", Husnr: "10", Etage: "3", Dør: "12", Postnr: "1610", Postnrnavn: "København V"}},
+		{"6. Julivej 12, kl, 6000 Kolding",
+			ParsedAdresse{Vejnavn: "6. Julivej", Husnr: "12", Etage: "kl", Postnr: "6000", Postnrnavn: "Kolding"}},
+		{"H.C. Andersens Boulevard 27, 1553 København V",
+			ParsedAdresse{Vejnavn: "H.C. Andersens Boulevard", Husnr: "27", Postnr: "1553", Postnrnavn: "København V"}},
+		{"Christian 10.s Gade 3, 6100 Haderslev",
+			ParsedAdresse{Vejnavn: "Christian 10.s Gade", Husnr: "3", Postnr: "6100", Postnrnavn: "Haderslev"}},
+		{"Christian 10.s Gade 3 2.tv, 6100 Haderslev",
+			ParsedAdresse{Vejnavn: "Christian 10.s Gade", Husnr: "3", Etage: "2", Dør: "tv", Postnr: "6100", Postnrnavn: "Haderslev"}},
+		{"Jens Hansen, Rødkildevej 46, 2400 København NV",
+			ParsedAdresse{Vejnavn: "Rødkildevej", Husnr: "46", Postnr: "2400", Postnrnavn: "København NV", Ukendt: "Jens Hansen"}},
+	}
+	for _, test := range tests {
+		got := ParseAdresse(test.in)
+		if got.Score <= 0 || got.Score > 1 {
+			t.Errorf("%q: unexpected score %v", test.in, got.Score)
+		}
+		got.Score = 0
+		if got != test.want {
+			t.Errorf("%q:\ngot  %#v\nwant %#v", test.in, got, test.want)
+		}
+	}
+
+	// Neither vejnavn nor postnummer.
+	for _, in := range []string{"", ",", "46"} {
+		if got := ParseAdresse(in); got.Score != 0 || got.Vejnavn != "" {
+			t.Errorf("%q: expected no vejnavn and score 0, got %#v", in, got)
+		}
+	}
+}
+
+func TestAdresseParserScore(t *testing.T) {
+	p := NewAdresseParser()
+	full := p.Parse("Rødkildevej 46, 1. tv, 2400 København NV")
+	if full.Score != 1 {
+		t.Fatalf("expected full score, got %v", full.Score)
+	}
+	for _, s := range []string{"Rødkildevej, 2400 København NV", "Rødkildevej 46", "Jens Hansen, Rødkildevej 46, 2400 København NV"} {
+		if r := p.Parse(s); r.Score >= full.Score {
+			t.Errorf("%q: expected lower score than %v, got %v", s, full.Score, r.Score)
+		}
+	}
+}
+
+func TestAdresseParserCorrections(t *testing.T) {
+	p := NewAdresseParser()
+	p.Vejnavne = NewVejnavnMatcher()
+	p.Vejnavne.Add("Rødkildevej")
+	p.Vejnavne.Add("Gammel Kongevej")
+	p.AddPostnummer("2400", "København NV")
+	p.AddPostnummer("1610", "København V")
+
+	r := p.Parse("rodkildevej 46, 1 tv, 2400 kobenhavn nv")
+	if r.Vejnavn != "Rødkildevej" || r.Postnrnavn != "København NV" || r.Score <= 0.5 || r.Score >= 1 {
+		t.Fatalf("unexpected result %#v", r)
+	}
+	r = p.Parse("gl kongevej 10, 1620 København V")
+	if r.Vejnavn != "Gammel Kongevej" || r.Postnr != "1610" || r.Postnrnavn != "København V" {
+		t.Fatalf("unexpected result %#v", r)
+	}
+
+	u := r.Query().URL()
+	if u != DefaultHost+"/adresser?vejnavn=Gammel+Kongevej&husnr=10&postnr=1610" {
+		t.Fatalf("unexpected url %s", u)
+	}
+}
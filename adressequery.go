@@ -163,10 +163,11 @@ func (q *AdresseQuery) AdgangsadresseID(s ...string) *AdresseQuery {
 //
 // Etagebetegnelse. Hvis værdi angivet kan den antage følgende værdier: tal fra 1 til 99, st, kl, kl2 op til kl9.
 // (Flerværdisøgning mulig). Søgning efter ingen værdi mulig.
+// Valid etagebetegnelser are normalized using ParseEtage, so "Stuen" is sent as "st".
 //
 // See documentation at http://dawa.aws.dk/adressedok#adressesoegning
 func (q *AdresseQuery) Etage(s ...string) *AdresseQuery {
-	q.add(&textQuery{Name: "etage", Values: s, Multi: true, Null: true})
+	q.add(&textQuery{Name: "etage", Values: etageValues(s), Multi: true, Null: true})
 	return q
 }
 
@@ -174,10 +175,11 @@ func (q *AdresseQuery) Etage(s ...string) *AdresseQuery {
 //
 // Dørbetegnelse. Tal fra 1 til 9999, små og store bogstaver samt tegnene / og -.
 // (Flerværdisøgning mulig). Søgning efter ingen værdi mulig.
+// Valid dørbetegnelser are normalized using ParseDør, so "TH." is sent as "th".
 //
 // See documentation at http://dawa.aws.dk/adressedok#adressesoegning
 func (q *AdresseQuery) Dør(s ...string) *AdresseQuery {
-	q.add(&textQuery{Name: "dør", Values: s, Multi: true, Null: true})
+	q.add(&textQuery{Name: "dør", Values: dørValues(s), Multi: true, Null: true})
 	return q
 }
 
@@ -228,10 +230,28 @@ func (q *AdresseQuery) Vejnavn(s ...string) *AdresseQuery {
 // Husnr will add a parameter for 'husnr' to the AdresseQuery.
 //
 // Husnummer. Max 4 cifre eventuelt med et efterfølgende bogstav. (Flerværdisøgning mulig).
+// Valid husnumre are normalized using ParseHusnr, so "012 b" is sent as "12B".
 //
 // See documentation at http://dawa.aws.dk/adressedok#adressesoegning
 func (q *AdresseQuery) Husnr(s ...string) *AdresseQuery {
-	q.add(&textQuery{Name: "husnr", Values: s, Multi: true, Null: false})
+	q.add(&textQuery{Name: "husnr", Values: husnrValues(s), Multi: true, Null: false})
+	return q
+}
+
+// HusnrInterval will add parameters for 'husnrfra' and 'husnrtil' to the AdresseQuery.
+//
+// Returnerer kun adresser hvor husnummeret er indenfor intervallet.
+// The side of the interval is not sent to the server, so use i.Contains() on the results
+// to select only even or odd husnumre.
+//
+// See documentation at http://dawa.aws.dk/adressedok#adressesoegning
+func (q *AdresseQuery) HusnrInterval(i HusnrInterval) *AdresseQuery {
+	if i.Fra != "" {
+		q.add(&textQuery{Name: "husnrfra", Values: []string{string(i.Fra)}, Multi: false, Null: false})
+	}
+	if i.Til != "" {
+		q.add(&textQuery{Name: "husnrtil", Values: []string{string(i.Til)}, Multi: false, Null: false})
+	}
 	return q
 }
 
@@ -10,14 +10,14 @@ import (
 
 type Adresse struct {
 	Adgangsadresse    AdgangsAdresse `json:"adgangsadresse"`    // Adressens adgangsadresse
-	Adressebetegnelse string         `json:"adressebetegnelse"` // (unknown)
-	Dør               string         `json:"dør"`               // Dørbetegnelse. Tal fra 1 til 9999, små og store bogstaver samt tegnene / og -.
-	Etage             string         `json:"etage"`             // Etagebetegnelse. Hvis værdi angivet kan den antage følgende værdier: tal fra 1 til 99, st, kl, kl2 op til kl9.
+	Adressebetegnelse string         `json:"adressebetegnelse"` // Adressens officielle adressebetegnelse. Se Adressebetegnelse().
+	Dør               Dør            `json:"dør"`               // Dørbetegnelse. Tal fra 1 til 9999, små og store bogstaver samt tegnene / og -.
+	Etage             Etage          `json:"etage"`             // Etagebetegnelse. Hvis værdi angivet kan den antage følgende værdier: tal fra 1 til 99, st, kl, kl2 op til kl9.
 	Historik          Historik       `json:"historik"`          // Væsentlige tidspunkter for adressen
 	Href              string         `json:"href"`              // Adgangsadressens URL.
 	ID                string         `json:"id"`                // Adressens unikke id, f.eks. 0a3f5095-45ec-32b8-e044-0003ba298018.
 	Kvhx              string         `json:"kvhx"`              // KVHX-nøgle. 19 tegn bestående af 4 cifre der repræsenterer kommunekode, 4 cifre der repræsenterer vejkode, 4 tegn der repræsenter husnr, 3 tegn der repræsenterer etage og 4 tegn der repræsenter dør.
-	Status            int            `json:"status"`            // Adressens status. 1 indikerer en gældende adresse, 3 indikerer en foreløbig adresse.
+	Status            Status         `json:"status"`            // Adressens status. 1 indikerer en gældende adresse, 3 indikerer en foreløbig adresse.
 }
 
 // AdresseIter is an Iterator that enable you to get individual entries.
@@ -25,21 +25,36 @@ type AdresseIter struct {
 	closer
 	a   chan Adresse
 	err error
+	validating
 }
 
 // Next will return addresses.
 // It will return an error if that has been encountered.
 // When there are not more entries nil, io.EOF will be returned.
 func (a *AdresseIter) Next() (*Adresse, error) {
-	v, ok := <-a.a
-	if ok {
-		return &v, nil
+	for {
+		v, ok := <-a.a
+		if !ok {
+			return nil, a.err
+		}
+		if !a.skip(&v) {
+			return &v, nil
+		}
 	}
-	return nil, a.err
+}
+
+// ValidateTo will make the iterator validate each record with its Validate method.
+// Invalid records are sent to the sink and skipped, instead of aborting the import.
+// It must be called before the first call to Next.
+func (a *AdresseIter) ValidateTo(sink ErrorSink) *AdresseIter {
+	a.sink = sink
+	return a
 }
 
 // ImportAdresserCSV will import "adresser" from a CSV file, supplied to the reader.
 // An iterator will be returned that return all addresses.
+// Unknown status, kilde, nøjagtighed, tekniskstandard and zone codes are kept in the records,
+// so they are reported by Validate and can be sent to the sink given to ValidateTo.
 func ImportAdresserCSV(in io.Reader) (*AdresseIter, error) {
 	r := csv.NewReader(in)
 	r.Comma = ','
@@ -70,11 +85,7 @@ func ImportAdresserCSV(in io.Reader) (*AdresseIter, error) {
 			// PROCESS: id,status,oprettet,ændret,vejkode,vejnavn,husnr,etage,dør,supplerendebynavn
 			a := Adresse{}
 			a.ID = v["id"]
-			a.Status, err = strconv.Atoi(v["status"])
-			if err != nil {
-				ret.err = err
-				return
-			}
+			a.Status = statusValue(v["status"])
 
 			// Example 2000-02-16T21:58:33.000
 			o, err := ParseTime(v["oprettet"])
@@ -93,8 +104,9 @@ func ImportAdresserCSV(in io.Reader) (*AdresseIter, error) {
 
 			a.Adgangsadresse.Vejstykke.Kode = v["vejkode"]
 			a.Adgangsadresse.Vejstykke.Navn = v["vejnavn"]
-			a.Adgangsadresse.Husnr = v["husnr"]
-			a.Etage = v["etage"]
+			a.Adgangsadresse.Husnr = Husnr(v["husnr"])
+			a.Etage = etageValue(v["etage"])
+			a.Dør = dørValue(v["dør"])
 			a.Adgangsadresse.SupplerendeBynavn = v["supplerendebynavn"]
 
 			// PROCESS: postnr,postnrnavn,kommunekode,kommunenavn,ejerlavkode,ejerlavnavn,matrikelnr,esrejendomsnr,etrs89koordinat_øst,etrs89koordinat_nord,wgs84koordinat_bredde,wgs84koordinat_længde,
@@ -114,10 +126,11 @@ func ImportAdresserCSV(in io.Reader) (*AdresseIter, error) {
 			a.Adgangsadresse.Adgangspunkt.Koordinater[1], _ = strconv.ParseFloat(v["wgs84koordinat_længde"], 64)
 
 			// PROCESS: nøjagtighed,kilde,tekniskstandard,tekstretning,ddkn_m100,ddkn_km1,ddkn_km10,adressepunktændringsdato,adgangsadresseid,adgangsadresse_status
-			a.Adgangsadresse.Adgangspunkt.Nøjagtighed = v["nøjagtighed"]
-			a.Adgangsadresse.Adgangspunkt.Kilde, _ = strconv.Atoi(v["kilde"])
-			a.Adgangsadresse.Adgangspunkt.Tekniskstandard = v["tekniskstandard"]
-			a.Adgangsadresse.Adgangspunkt.Tekstretning, _ = strconv.ParseFloat(v["tekstretning"], 64)
+			a.Adgangsadresse.Adgangspunkt.Nøjagtighed = nøjagtighedValue(v["nøjagtighed"])
+			a.Adgangsadresse.Adgangspunkt.Kilde = kildeValue(v["kilde"])
+			a.Adgangsadresse.Adgangspunkt.Tekniskstandard = tekniskstandardValue(v["tekniskstandard"])
+			tekstretning, _ := strconv.ParseFloat(v["tekstretning"], 64)
+			a.Adgangsadresse.Adgangspunkt.Tekstretning = Tekstretning(tekstretning)
 			a.Adgangsadresse.DDKN.M100 = v["ddkn_m100"]
 			a.Adgangsadresse.DDKN.Km1 = v["ddkn_km1"]
 			a.Adgangsadresse.DDKN.Km10 = v["ddkn_km10"]
@@ -127,8 +140,11 @@ func ImportAdresserCSV(in io.Reader) (*AdresseIter, error) {
 				return
 			}
 			a.Adgangsadresse.Adgangspunkt.Ændret = *o
+			if h, err := strconv.ParseFloat(v["højde"], 64); err == nil {
+				a.Adgangsadresse.Adgangspunkt.Højde = &h
+			}
 			a.Adgangsadresse.ID = v["adgangsadresseid"]
-			a.Adgangsadresse.Status, _ = strconv.Atoi(v["adgangsadresse_status"])
+			a.Adgangsadresse.Status = statusValue(v["adgangsadresse_status"])
 
 			// PROCESS: adgangsadresse_oprettet,adgangsadresse_ændret,kvhx,regionskode,regionsnavn,sognekode,sognenavn,politikredskode,politikredsnavn,retskredskode,retskredsnavn
 			o, err = ParseTime(v["adgangsadresse_oprettet"])
@@ -157,7 +173,20 @@ func ImportAdresserCSV(in io.Reader) (*AdresseIter, error) {
 			// opstillingskredskode,opstillingskredsnavn,zone
 			a.Adgangsadresse.Opstillingskreds.Kode = v["opstillingskredskode"]
 			a.Adgangsadresse.Opstillingskreds.Navn = v["opstillingskredsnavn"]
-			a.Adgangsadresse.Zone = v["zone"]
+			a.Adgangsadresse.Zone = zoneValue(v["zone"])
+
+			// storkredsnummer,storkredsnavn,valglandsdelsbogstav,valglandsdelsnavn,landsdelsnuts3,landsdelsnavn,
+			// afstemningsområdenummer,afstemningsområdenavn,menighedsrådsafstemningsområdenummer,menighedsrådsafstemningsområdenavn
+			a.Adgangsadresse.Storkreds.Nummer = v["storkredsnummer"]
+			a.Adgangsadresse.Storkreds.Navn = v["storkredsnavn"]
+			a.Adgangsadresse.Valglandsdel.Bogstav = v["valglandsdelsbogstav"]
+			a.Adgangsadresse.Valglandsdel.Navn = v["valglandsdelsnavn"]
+			a.Adgangsadresse.Landsdel.Nuts3 = v["landsdelsnuts3"]
+			a.Adgangsadresse.Landsdel.Navn = v["landsdelsnavn"]
+			a.Adgangsadresse.Afstemningsområde.Nummer = v["afstemningsområdenummer"]
+			a.Adgangsadresse.Afstemningsområde.Navn = v["afstemningsområdenavn"]
+			a.Adgangsadresse.Menighedsrådsafstemningsområde.Nummer = v["menighedsrådsafstemningsområdenummer"]
+			a.Adgangsadresse.Menighedsrådsafstemningsområde.Navn = v["menighedsrådsafstemningsområdenavn"]
 			ret.a <- a
 		}
 	}()
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -414,3 +415,19 @@ func TestImportAdresserJSON(t *testing.T) {
 		t.Fatalf("ImportAdresserJSON: Expected io.EOF, got:%v", err)
 	}
 }
+
+func TestImportAdresserCSVEtageDør(t *testing.T) {
+	lines := strings.Split(csv_data, "\n")
+	row := strings.Replace(lines[1], ",6,,,Vråby,", ",6,01,TH.,Vråby,", 1)
+	iter, err := ImportAdresserCSV(strings.NewReader(lines[0] + "\n" + row + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := iter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Etage != "1" || a.Dør != DørTh {
+		t.Fatalf("unexpected etage and dør: %q, %q", a.Etage, a.Dør)
+	}
+}
@@ -0,0 +1,179 @@
+package dawa
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Adressebetegnelse returns the official one-line adressebetegnelse of the adresse,
+// like "Rødkildevej 46, 1. tv, 2400 København NV".
+// The supplerende bynavn is included if set, like "Ådalen 4, Øster Assels, 7990 Øster Assels".
+func Adressebetegnelse(a *Adresse) string {
+	return adressebetegnelse(&a.Adgangsadresse, a.Etage, a.Dør)
+}
+
+// AdgangsAdressebetegnelse returns the official one-line adressebetegnelse of the adgangsadresse,
+// like "Rødkildevej 46, 2400 København NV".
+func AdgangsAdressebetegnelse(a *AdgangsAdresse) string {
+	return adressebetegnelse(a, "", "")
+}
+
+func adressebetegnelse(a *AdgangsAdresse, e Etage, d Dør) string {
+	s := adresseringVejLinje(a.Vejstykke.Navn, a.Husnr, e, d)
+	if a.SupplerendeBynavn != "" {
+		s += ", " + a.SupplerendeBynavn
+	}
+	if p := adresseringPostLinje(a.Postnummer); p != "" {
+		s += ", " + p
+	}
+	return s
+}
+
+// Adressering creates postal labels for letters and window envelopes.
+//
+// A label has a line with vejnavn, husnr, etage and dør, an optional line with the supplerende bynavn
+// and a line with postnummer and postnummernavn, for example:
+//			Rødkildevej 46, 1. tv
+//			2400 København NV
+//
+// If the first line is wider than Bredde, the adresseringsnavn of the vejstykke is used instead of the vejnavn.
+// Adresseringsnavne are added with AddVejstykke or LoadVejstykker.
+// Lines are never truncated, so a line may still be too wide if no shorter form is known.
+//
+// Adgangsadresser belonging to a stormodtager are added with AddStormodtager,
+// and will have the stormodtager postnummer on the postnummer line.
+//
+// The object is not safe for concurrent modification, but
+// labels can be created concurrently when it is no longer modified.
+//
+// Use NewAdressering() to get an initialized object.
+// Example:
+//			ad := dawa.NewAdressering(30)
+//			err := ad.LoadVejstykker(iter)
+//			lines := ad.Label(&adresse)
+type Adressering struct {
+	// Bredde is the maximum number of characters on a line.
+	// 0 means no limit, so the adresseringsnavn is never used.
+	Bredde int
+
+	adresseringsnavne map[indexVejKey]string
+	stormodtagere     map[string]PostnummerRef
+}
+
+// NewAdressering returns a new Adressering with the specified line width.
+func NewAdressering(bredde int) *Adressering {
+	return &Adressering{
+		Bredde:            bredde,
+		adresseringsnavne: make(map[indexVejKey]string),
+		stormodtagere:     make(map[string]PostnummerRef),
+	}
+}
+
+// AddVejstykke will add the adresseringsnavn of the vejstykke.
+// False is returned if the vejstykke has no adresseringsnavn or an invalid kommunekode or vejkode.
+func (ad *Adressering) AddVejstykke(v *Vejstykke) bool {
+	if v.Adresseringsnavn == "" {
+		return false
+	}
+	k, err := strconv.Atoi(v.Kommune.Kode)
+	if err != nil {
+		return false
+	}
+	n, err := strconv.Atoi(v.Kode)
+	if err != nil {
+		return false
+	}
+	ad.adresseringsnavne[indexVejKey{kommune: k, vej: n}] = v.Adresseringsnavn
+	return true
+}
+
+// LoadVejstykker will add the adresseringsnavne of all vejstykker from the iterator.
+// The iterator is read until io.EOF is returned.
+func (ad *Adressering) LoadVejstykker(iter *VejstykkeIter) error {
+	for {
+		v, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ad.AddVejstykke(v)
+	}
+}
+
+// AddStormodtager will register the stormodtageradresser of the postnummer,
+// so labels of these adgangsadresser use the postnummer.
+func (ad *Adressering) AddStormodtager(p *Postnummer) {
+	for _, a := range p.Stormodtageradresser {
+		ad.stormodtagere[a.ID] = PostnummerRef{Href: p.Href, Nr: p.Nr, Navn: p.Navn}
+	}
+}
+
+// Label returns the lines of the postal label of the adresse.
+func (ad *Adressering) Label(a *Adresse) []string {
+	return ad.label(&a.Adgangsadresse, a.Etage, a.Dør)
+}
+
+// AdgangsAdresseLabel returns the lines of the postal label of the adgangsadresse.
+func (ad *Adressering) AdgangsAdresseLabel(a *AdgangsAdresse) []string {
+	return ad.label(a, "", "")
+}
+
+// Adressebetegnelse returns the lines of the label joined by ", ".
+// Unlike the official Adressebetegnelse, the adresseringsnavn and stormodtager postnummer are used.
+func (ad *Adressering) Adressebetegnelse(a *Adresse) string {
+	return strings.Join(ad.Label(a), ", ")
+}
+
+func (ad *Adressering) label(a *AdgangsAdresse, e Etage, d Dør) []string {
+	vej := adresseringVejLinje(a.Vejstykke.Navn, a.Husnr, e, d)
+	if ad.Bredde > 0 && utf8.RuneCountInString(vej) > ad.Bredde {
+		if k, ok := newIndexVejKey(a); ok {
+			if navn := ad.adresseringsnavne[indexVejKey{kommune: k.kommune, vej: k.vej}]; navn != "" {
+				vej = adresseringVejLinje(navn, a.Husnr, e, d)
+			}
+		}
+	}
+	var res []string
+	if vej != "" {
+		res = append(res, vej)
+	}
+	if a.SupplerendeBynavn != "" {
+		res = append(res, a.SupplerendeBynavn)
+	}
+	post := a.Postnummer
+	if p, ok := ad.stormodtagere[a.ID]; ok {
+		post = p
+	}
+	if p := adresseringPostLinje(post); p != "" {
+		res = append(res, p)
+	}
+	return res
+}
+
+// adresseringVejLinje returns the line with vejnavn, husnr, etage and dør,
+// like "Rødkildevej 46, 1. tv".
+func adresseringVejLinje(vejnavn string, h Husnr, e Etage, d Dør) string {
+	s := strings.TrimSpace(vejnavn + " " + string(h))
+	if e == "" && d == "" {
+		return s
+	}
+	// The official form has a dot after the etage, but not after the dør.
+	var parts []string
+	if e != "" {
+		parts = append(parts, e.Formateret())
+	}
+	if d != "" {
+		parts = append(parts, string(d))
+	}
+	return s + ", " + strings.Join(parts, " ")
+}
+
+// adresseringPostLinje returns the line with postnummer and postnummernavn,
+// like "2400 København NV".
+func adresseringPostLinje(p PostnummerRef) string {
+	return strings.TrimSpace(p.Nr + " " + p.Navn)
+}
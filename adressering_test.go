@@ -0,0 +1,71 @@
+package dawa
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAdressebetegnelse(t *testing.T) {
+	var a Adresse
+	a.Adgangsadresse.Vejstykke = VejstykkeRef{Kode: "6100", Navn: "Rødkildevej"}
+	a.Adgangsadresse.Kommune.Kode = "0101"
+	a.Adgangsadresse.Husnr = "46"
+	a.Adgangsadresse.Postnummer = PostnummerRef{Nr: "2400", Navn: "København NV"}
+	a.Etage = "1"
+	a.Dør = "tv"
+	if s := Adressebetegnelse(&a); s != "Rødkildevej 46, 1. tv, 2400 København NV" {
+		t.Fatalf("unexpected adressebetegnelse %q", s)
+	}
+	if s := AdgangsAdressebetegnelse(&a.Adgangsadresse); s != "Rødkildevej 46, 2400 København NV" {
+		t.Fatalf("unexpected adressebetegnelse %q", s)
+	}
+	a.Adgangsadresse.SupplerendeBynavn = "Utterslev"
+	if s := Adressebetegnelse(&a); s != "Rødkildevej 46, 1. tv, Utterslev, 2400 København NV" {
+		t.Fatalf("unexpected adressebetegnelse %q", s)
+	}
+}
+
+func TestAdresseringLabel(t *testing.T) {
+	var a Adresse
+	a.Adgangsadresse.ID = "aa1"
+	a.Adgangsadresse.Vejstykke = VejstykkeRef{Kode: "0612", Navn: "Frederiksberg Allé"}
+	a.Adgangsadresse.Kommune.Kode = "0147"
+	a.Adgangsadresse.Husnr = "102"
+	a.Adgangsadresse.SupplerendeBynavn = "Bakkehuset"
+	a.Adgangsadresse.Postnummer = PostnummerRef{Nr: "1820", Navn: "Frederiksberg C"}
+	a.Etage = "st"
+	a.Dør = "th"
+
+	ad := NewAdressering(0)
+	want := []string{"Frederiksberg Allé 102, st. th", "Bakkehuset", "1820 Frederiksberg C"}
+	if got := ad.Label(&a); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	// Too wide, but no adresseringsnavn known.
+	ad.Bredde = 20
+	if got := ad.Label(&a); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if !ad.AddVejstykke(&Vejstykke{Kode: "612", Kommune: KommuneRef{Kode: "147"}, Adresseringsnavn: "Fr.berg Allé"}) {
+		t.Fatal("expected vejstykke to be added")
+	}
+	if ad.AddVejstykke(&Vejstykke{Kode: "1", Kommune: KommuneRef{Kode: "147"}}) {
+		t.Fatal("expected vejstykke without adresseringsnavn to be skipped")
+	}
+	want[0] = "Fr.berg Allé 102, st. th"
+	if got := ad.Label(&a); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	// Wide enough for the full vejnavn.
+	ad.Bredde = 30
+	if got := ad.AdgangsAdresseLabel(&a.Adgangsadresse); got[0] != "Frederiksberg Allé 102" {
+		t.Fatalf("unexpected label %q", got)
+	}
+
+	ad.AddStormodtager(&Postnummer{Nr: "1999", Navn: "København V", Stormodtageradresser: []AdgangsAdresseRef{{ID: "aa1"}}})
+	if s := ad.Adressebetegnelse(&a); s != "Frederiksberg Allé 102, st. th, Bakkehuset, 1999 København V" {
+		t.Fatalf("unexpected adressebetegnelse %q", s)
+	}
+}
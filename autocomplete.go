@@ -0,0 +1,331 @@
+package dawa
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Autocomplete types, as returned in the Type field of autocomplete results.
+const (
+	AutocompleteVejnavn        = "vejnavn"
+	AutocompleteAdgangsAdresse = "adgangsadresse"
+	AutocompleteAdresse        = "adresse"
+)
+
+// Autocomplete is an offline autocomplete engine for vejnavne, adgangsadresser and adresser.
+//
+// The search text is matched like the 'q' parameter of the online API:
+// All words in the search text must match a word in the text of the result.
+// The wildcard * is allowed at the end of each word to match words with that prefix.
+// Since the user is expected to be typing, the last word is always matched as a prefix,
+// unless the search text ends with a space.
+// Other words must match a whole word, so "rødkilde 46" doesn't match "Rødkildevej 46",
+// but "rødkildevej 46" and "rødkilde* 46" do.
+// Upper and lower case, including æ, ø and å, are not distinguished.
+//
+// Results are returned as AdgangsAdresse objects with Text, Type and
+// the AutocompleteAddress fields set, like results from NewAdgangsAdresseComplete().
+// Results of type "adgangsadresse" and "adresse" also have the remaining fields
+// of the adgangsadresse set.
+//
+// The engine is not safe for concurrent modification, but
+// searches can be done concurrently when it is no longer modified.
+//
+// Use NewAutocomplete() to get an initialized object.
+// Example:
+//			ac := dawa.NewAutocomplete()
+//			err := ac.LoadAdresser(iter)
+//			res := ac.Search("rødkildevej 46", 10)
+type Autocomplete struct {
+	entries []autocompleteEntry
+	words   []autocompleteWord
+	sorted  bool
+	sortMu  sync.Mutex
+	vejnavn map[string]bool
+}
+
+type autocompleteEntry struct {
+	typ   string
+	text  string
+	words []string
+	aa    *AdgangsAdresse
+	id    string
+	etage string
+	dør   string
+}
+
+type autocompleteWord struct {
+	word  string
+	entry int32
+}
+
+// NewAutocomplete returns a new, empty Autocomplete engine.
+func NewAutocomplete() *Autocomplete {
+	return &Autocomplete{vejnavn: make(map[string]bool)}
+}
+
+// LoadVejstykker will add the names of all vejstykker from the iterator.
+// The iterator is read until io.EOF is returned.
+func (ac *Autocomplete) LoadVejstykker(iter *VejstykkeIter) error {
+	for {
+		v, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ac.AddVejnavn(v.Navn)
+	}
+}
+
+// LoadAdgangsAdresser will add all adgangsadresser from the iterator.
+// The iterator is read until io.EOF is returned.
+func (ac *Autocomplete) LoadAdgangsAdresser(iter *AdgangsAdresseIter) error {
+	for {
+		a, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ac.AddAdgangsAdresse(a)
+	}
+}
+
+// LoadAdresser will add all adresser from the iterator.
+// The iterator is read until io.EOF is returned.
+func (ac *Autocomplete) LoadAdresser(iter *AdresseIter) error {
+	for {
+		a, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ac.AddAdresse(a)
+	}
+}
+
+// AddVejnavn will add a vejnavn.
+// Each vejnavn is only added once, even if it is present in several kommuner.
+func (ac *Autocomplete) AddVejnavn(navn string) {
+	key := strings.ToLower(navn)
+	if navn == "" || ac.vejnavn[key] {
+		return
+	}
+	ac.vejnavn[key] = true
+	ac.add(autocompleteEntry{typ: AutocompleteVejnavn, text: navn})
+}
+
+// AddAdgangsAdresse will add an adgangsadresse.
+// The vejnavn of the adgangsadresse is also added.
+func (ac *Autocomplete) AddAdgangsAdresse(a *AdgangsAdresse) {
+	ac.AddVejnavn(a.Vejstykke.Navn)
+	ac.add(autocompleteEntry{typ: AutocompleteAdgangsAdresse, text: AdgangsAdressebetegnelse(a), aa: a, id: a.ID})
+}
+
+// AddAdresse will add an adresse.
+// The vejnavn of the adresse is also added, but not the adgangsadresse.
+func (ac *Autocomplete) AddAdresse(a *Adresse) {
+	aa := &a.Adgangsadresse
+	ac.AddVejnavn(aa.Vejstykke.Navn)
+	ac.add(autocompleteEntry{typ: AutocompleteAdresse, text: Adressebetegnelse(a), aa: aa, id: a.ID, etage: string(a.Etage), dør: string(a.Dør)})
+}
+
+func (ac *Autocomplete) add(e autocompleteEntry) {
+	e.words = autocompleteWords(e.text)
+	n := int32(len(ac.entries))
+	ac.entries = append(ac.entries, e)
+	for _, w := range e.words {
+		ac.words = append(ac.words, autocompleteWord{word: w, entry: n})
+	}
+	ac.sorted = false
+}
+
+// Search returns up to limit results matching the search text.
+// If limit is 0 or less, all results are returned.
+//
+// Only the last word is matched as a prefix, see Autocomplete.
+// Vejnavne are returned first, then adgangsadresser and finally adresser.
+// Vejnavne are ordered by text. Adgangsadresser and adresser are ordered
+// by vejnavn, husnr, etage and dør, so "Vej 2" comes before "Vej 10".
+func (ac *Autocomplete) Search(q string, limit int) []AdgangsAdresse {
+	terms := autocompleteTerms(q)
+	if len(terms) == 0 {
+		return nil
+	}
+	ac.sortMu.Lock()
+	if !ac.sorted {
+		sort.Sort(autocompleteByWord(ac.words))
+		ac.sorted = true
+	}
+	ac.sortMu.Unlock()
+
+	// Find the term with the fewest candidates.
+	var candidates []autocompleteWord
+	for i, t := range terms {
+		c := ac.lookup(t)
+		if i == 0 || len(c) < len(candidates) {
+			candidates = c
+		}
+	}
+
+	seen := make(map[int32]bool)
+	var found []*autocompleteEntry
+	for _, c := range candidates {
+		if seen[c.entry] {
+			continue
+		}
+		seen[c.entry] = true
+		e := &ac.entries[c.entry]
+		if e.matches(terms) {
+			found = append(found, e)
+		}
+	}
+	sort.Sort(autocompleteByType(found))
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+	res := make([]AdgangsAdresse, len(found))
+	for i, e := range found {
+		res[i] = e.result()
+	}
+	return res
+}
+
+// lookup returns all words matching the term.
+func (ac *Autocomplete) lookup(t autocompleteTerm) []autocompleteWord {
+	w := ac.words
+	i := sort.Search(len(w), func(i int) bool { return w[i].word >= t.word })
+	j := i
+	for j < len(w) && t.match(w[j].word) {
+		j++
+	}
+	return w[i:j]
+}
+
+func (e *autocompleteEntry) matches(terms []autocompleteTerm) bool {
+	for _, t := range terms {
+		ok := false
+		for _, w := range e.words {
+			if t.match(w) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// result returns the entry in the format of the online autocomplete.
+func (e *autocompleteEntry) result() AdgangsAdresse {
+	var a AdgangsAdresse
+	if e.aa != nil {
+		a = *e.aa
+		a.AutocompleteAddress = AutocompleteAddress{
+			ID:         e.id,
+			Street:     e.aa.Vejstykke.Navn,
+			Husnr:      e.aa.Husnr,
+			PostNumber: e.aa.Postnummer.Nr,
+			PostName:   e.aa.Postnummer.Navn,
+		}
+		// Like the online autocomplete, Floor and Door are nil if the adresse has no etage or dør.
+		if e.etage != "" {
+			etage := e.etage
+			a.AutocompleteAddress.Floor = &etage
+		}
+		if e.dør != "" {
+			dør := e.dør
+			a.AutocompleteAddress.Door = &dør
+		}
+	} else {
+		a.AutocompleteAddress.Street = e.text
+	}
+	a.Text = e.text
+	a.Type = e.typ
+	return a
+}
+
+// autocompleteTerm is a single word of a search text.
+type autocompleteTerm struct {
+	word   string
+	prefix bool
+}
+
+func (t autocompleteTerm) match(w string) bool {
+	if t.prefix {
+		return strings.HasPrefix(w, t.word)
+	}
+	return w == t.word
+}
+
+// autocompleteTerms splits a search text into terms.
+func autocompleteTerms(q string) []autocompleteTerm {
+	var res []autocompleteTerm
+	for _, f := range strings.Fields(q) {
+		prefix := strings.HasSuffix(f, "*")
+		for _, w := range autocompleteWords(f) {
+			res = append(res, autocompleteTerm{word: w})
+		}
+		if prefix && len(res) > 0 {
+			res[len(res)-1].prefix = true
+		}
+	}
+	if len(res) > 0 && !strings.HasSuffix(q, " ") {
+		res[len(res)-1].prefix = true
+	}
+	return res
+}
+
+// autocompleteWords returns the lower case words of s.
+// Anything but letters and digits separate words.
+func autocompleteWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+type autocompleteByWord []autocompleteWord
+
+func (a autocompleteByWord) Len() int           { return len(a) }
+func (a autocompleteByWord) Less(i, j int) bool { return a[i].word < a[j].word }
+func (a autocompleteByWord) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+var autocompleteTypeOrder = map[string]int{AutocompleteVejnavn: 0, AutocompleteAdgangsAdresse: 1, AutocompleteAdresse: 2}
+
+type autocompleteByType []*autocompleteEntry
+
+func (a autocompleteByType) Len() int      { return len(a) }
+func (a autocompleteByType) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a autocompleteByType) Less(i, j int) bool {
+	ti, tj := autocompleteTypeOrder[a[i].typ], autocompleteTypeOrder[a[j].typ]
+	if ti != tj {
+		return ti < tj
+	}
+	if a[i].aa == nil || a[j].aa == nil {
+		return a[i].text < a[j].text
+	}
+	ai, aj := a[i].aa, a[j].aa
+	if ai.Vejstykke.Navn != aj.Vejstykke.Navn {
+		return ai.Vejstykke.Navn < aj.Vejstykke.Navn
+	}
+	if c := CompareHusnr(ai.Husnr, aj.Husnr); c != 0 {
+		return c < 0
+	}
+	if c := CompareEtage(Etage(a[i].etage), Etage(a[j].etage)); c != 0 {
+		return c < 0
+	}
+	if c := CompareDør(Dør(a[i].dør), Dør(a[j].dør)); c != 0 {
+		return c < 0
+	}
+	return a[i].text < a[j].text
+}
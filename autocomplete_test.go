@@ -0,0 +1,111 @@
+package dawa
+
+import (
+	"bytes"
+	"testing"
+)
+
+var autocomplete_adresse_input = `[
+{"id":"ad1","etage":"1","dør":"tv","adgangsadresse":{"id":"aa1","husnr":"46","vejstykke":{"navn":"Rødkildevej"},"postnummer":{"nr":"2400","navn":"København NV"}}},
+{"id":"ad2","etage":"2","dør":"th","adgangsadresse":{"id":"aa1","husnr":"46","vejstykke":{"navn":"Rødkildevej"},"postnummer":{"nr":"2400","navn":"København NV"}}},
+{"id":"ad3","etage":"","dør":"","adgangsadresse":{"id":"aa2","husnr":"4","vejstykke":{"navn":"Ådalen"},"supplerendebynavn":"Øster Assels","postnummer":{"nr":"7990","navn":"Øster Assels"}}}
+]`
+
+func TestAutocomplete(t *testing.T) {
+	ac := NewAutocomplete()
+	iter, err := ImportAdresserJSON(bytes.NewBufferString(autocomplete_adresse_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ac.LoadAdresser(iter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ac.AddAdgangsAdresse(&AdgangsAdresse{ID: "aa1", Husnr: "46", Vejstykke: VejstykkeRef{Navn: "Rødkildevej"}, Postnummer: PostnummerRef{Nr: "2400", Navn: "København NV"}})
+	ac.AddVejnavn("Rødkildevej")
+	ac.AddVejnavn("Rødovrevej")
+
+	res := ac.Search("RØD", 0)
+	if len(res) != 5 {
+		t.Fatalf("expected 5 results, got %d: %#v", len(res), res)
+	}
+	if res[0].Type != AutocompleteVejnavn || res[0].Text != "Rødkildevej" || res[0].AutocompleteAddress.Street != "Rødkildevej" {
+		t.Fatalf("unexpected first result: %#v", res[0])
+	}
+	if res[1].Text != "Rødovrevej" {
+		t.Fatalf("unexpected second result: %#v", res[1])
+	}
+	if res[2].Type != AutocompleteAdgangsAdresse || res[2].Text != "Rødkildevej 46, 2400 København NV" || res[2].AutocompleteAddress.ID != "aa1" {
+		t.Fatalf("unexpected adgangsadresse result: %#v", res[2])
+	}
+	a := res[3]
+	if a.Type != AutocompleteAdresse || a.Text != "Rødkildevej 46, 1. tv, 2400 København NV" || a.AutocompleteAddress.ID != "ad1" {
+		t.Fatalf("unexpected adresse result: %#v", a)
+	}
+	if d := a.AutocompleteAddress; d.Floor == nil || *d.Floor != "1" || d.Door == nil || *d.Door != "tv" || d.PostNumber != "2400" || d.Husnr != "46" {
+		t.Fatalf("unexpected autocomplete data: %#v", a.AutocompleteAddress)
+	}
+
+	// All words must match, and limit is respected.
+	if res := ac.Search("rødkildevej 46 2 th", 0); len(res) != 1 || res[0].AutocompleteAddress.ID != "ad2" {
+		t.Fatalf("unexpected result: %#v", res)
+	}
+	if res := ac.Search("rødkildevej", 2); len(res) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(res))
+	}
+	// Words without wildcard must match exactly, unless it is the last word.
+	if res := ac.Search("rødkilde 46", 0); len(res) != 0 {
+		t.Fatalf("expected no results, got %d", len(res))
+	}
+	if res := ac.Search("rødkilde* 46", 0); len(res) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(res))
+	}
+	if res := ac.Search("rødkildevej 4 ", 0); len(res) != 0 {
+		t.Fatalf("expected no results, got %d", len(res))
+	}
+	// Danish case folding
+	if res := ac.Search("ÅDALEN ØSTER", 0); len(res) != 1 || res[0].Text != "Ådalen 4, Øster Assels, 7990 Øster Assels" {
+		t.Fatalf("unexpected result: %#v", res)
+	}
+	// No etage and dør is returned as nil, like the online autocomplete.
+	res = ac.Search("ådalen 4", 0)
+	if len(res) == 0 || res[len(res)-1].Type != AutocompleteAdresse {
+		t.Fatalf("expected adresse result: %#v", res)
+	}
+	for _, a := range res {
+		if a.AutocompleteAddress.Floor != nil || a.AutocompleteAddress.Door != nil {
+			t.Fatalf("expected nil etage and dør: %#v", a.AutocompleteAddress)
+		}
+	}
+	if res := ac.Search("", 0); len(res) != 0 {
+		t.Fatalf("expected no results on empty search, got %d", len(res))
+	}
+}
+
+func TestAutocompleteOrder(t *testing.T) {
+	ac := NewAutocomplete()
+	for _, h := range []Husnr{"10", "2", "2B", "1"} {
+		ac.AddAdgangsAdresse(&AdgangsAdresse{ID: "aa" + string(h), Husnr: h, Vejstykke: VejstykkeRef{Navn: "Vej"}})
+	}
+	aa := AdgangsAdresse{ID: "aa2", Husnr: "2", Vejstykke: VejstykkeRef{Navn: "Vej"}}
+	for _, e := range []struct{ etage, dør string }{{"10", "th"}, {"2", "tv"}, {"st", "th"}, {"2", "th"}, {"kl", ""}} {
+		ac.AddAdresse(&Adresse{ID: "ad" + e.etage + e.dør, Etage: Etage(e.etage), Dør: Dør(e.dør), Adgangsadresse: aa})
+	}
+	var got []string
+	for _, r := range ac.Search("vej", 0) {
+		got = append(got, r.Text)
+	}
+	want := []string{
+		"Vej",
+		"Vej 1", "Vej 2", "Vej 2B", "Vej 10",
+		"Vej 2, kl.", "Vej 2, st. th", "Vej 2, 2. tv", "Vej 2, 2. th", "Vej 2, 10. th",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected results: %q", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("result %d: got %q, want %q (all: %q)", i, got[i], want[i], got)
+		}
+	}
+}
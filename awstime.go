@@ -56,10 +56,12 @@ func (t *AwsTime) UnmarshalJSON(b []byte) error {
 	unquoted := strings.Trim(string(b), "\" ")
 	result, err := time.ParseInLocation("2006-01-02T15:04:05.000", unquoted, location)
 
-	// Could not parse, attempt standard unmarshall
+	// Could not parse, attempt standard time format
 	if err != nil {
-		var t2 time.Time
-		err = t2.UnmarshalJSON([]byte(unquoted))
+		if unquoted == "" || unquoted == "null" {
+			return nil
+		}
+		t2, err := time.Parse(time.RFC3339Nano, unquoted)
 		if err != nil {
 			return err
 		}
@@ -91,6 +93,16 @@ func (t *AwsTime) GobDecode(data []byte) error {
 	return (*time.Time)(t).GobDecode(data)
 }
 
+// MarshalBinary (as time.Time)
+func (t AwsTime) MarshalBinary() ([]byte, error) {
+	return time.Time(t).MarshalBinary()
+}
+
+// UnmarshalBinary (as time.Time)
+func (t *AwsTime) UnmarshalBinary(data []byte) error {
+	return (*time.Time)(t).UnmarshalBinary(data)
+}
+
 /*
 // GetBSON provides BSON encoding of the Kid
 func (t AwsTime) GetBSON() (interface{}, error) {
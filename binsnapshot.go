@@ -0,0 +1,604 @@
+package dawa
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// BinarySnapshotVersion is the version of the binary snapshot format
+// written by this version of the package.
+// Snapshots written with a different version cannot be read, and must be recreated.
+const BinarySnapshotVersion = 2
+
+// binarySnapshotMagic identifies a binary snapshot file.
+var binarySnapshotMagic = [8]byte{'D', 'A', 'W', 'A', 'S', 'N', 'A', 'P'}
+
+const binarySnapshotHeaderSize = 64
+
+var (
+	// ErrBinarySnapshotFormat is returned if the data is not a binary snapshot, or is truncated.
+	ErrBinarySnapshotFormat = errors.New("binary snapshot: invalid format")
+
+	// ErrBinarySnapshotVersion is returned if the binary snapshot was written with another format version.
+	ErrBinarySnapshotVersion = errors.New("binary snapshot: unsupported version")
+)
+
+// BinarySnapshotWriter creates a compact binary snapshot of adgangsadresser and adresser,
+// that can be loaded quickly with OpenBinarySnapshot().
+//
+// Data is stored in columns, and names of kommuner, veje, postnumre and other
+// repeated strings are only stored once.
+//
+// Only the following fields are stored:
+// For adgangsadresser ID, Status, Kommune.Kode, Kommune.Navn, Vejstykke.Kode, Vejstykke.Navn,
+// Husnr, SupplerendeBynavn, Postnummer.Nr, Postnummer.Navn, Ejerlav.Kode, Matrikelnr and
+// Adgangspunkt.Koordinater.
+// For adresser ID, Status, Etage, Dør and the adgangsadresse.
+//
+// IDs must be UUIDs, as used by DAWA.
+//
+// Use NewBinarySnapshotWriter() to get an initialized object.
+// Example:
+//			iter, err := dawa.ImportAdresserCSV(in)
+//			w := dawa.NewBinarySnapshotWriter()
+//			err = w.LoadAdresser(iter)
+//			_, err = w.WriteTo(out)
+type BinarySnapshotWriter struct {
+	// Sekvensnummer of the data, if known.
+	// It can be used to detect if the snapshot is outdated.
+	Sekvensnummer int64
+
+	aa      []AdgangsAdresse
+	aaIndex map[string]int
+	ad      []Adresse
+	adIndex map[string]int
+}
+
+// NewBinarySnapshotWriter returns a new, empty BinarySnapshotWriter.
+func NewBinarySnapshotWriter() *BinarySnapshotWriter {
+	return &BinarySnapshotWriter{aaIndex: make(map[string]int), adIndex: make(map[string]int)}
+}
+
+// LoadAdgangsAdresser will add all adgangsadresser from the iterator.
+// The iterator is read until io.EOF is returned.
+func (w *BinarySnapshotWriter) LoadAdgangsAdresser(iter *AdgangsAdresseIter) error {
+	for {
+		a, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		w.AddAdgangsAdresse(a)
+	}
+}
+
+// LoadAdresser will add all adresser from the iterator.
+// The iterator is read until io.EOF is returned.
+func (w *BinarySnapshotWriter) LoadAdresser(iter *AdresseIter) error {
+	for {
+		a, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		w.AddAdresse(a)
+	}
+}
+
+// AddAdgangsAdresse will add an adgangsadresse.
+// If an adgangsadresse with the same ID has been added it is replaced.
+func (w *BinarySnapshotWriter) AddAdgangsAdresse(a *AdgangsAdresse) {
+	if i, ok := w.aaIndex[a.ID]; ok {
+		w.aa[i] = *a
+		return
+	}
+	w.aaIndex[a.ID] = len(w.aa)
+	w.aa = append(w.aa, *a)
+}
+
+// AddAdresse will add an adresse.
+// If an adresse with the same ID has been added it is replaced.
+// If the adgangsadresse of the adresse has not been added, and
+// the adresse contains the adgangsadresse data, it is also added.
+func (w *BinarySnapshotWriter) AddAdresse(a *Adresse) {
+	if _, ok := w.aaIndex[a.Adgangsadresse.ID]; !ok && a.Adgangsadresse.ID != "" && a.Adgangsadresse.Husnr != "" {
+		w.AddAdgangsAdresse(&a.Adgangsadresse)
+	}
+	if i, ok := w.adIndex[a.ID]; ok {
+		w.ad[i] = *a
+		return
+	}
+	w.adIndex[a.ID] = len(w.ad)
+	w.ad = append(w.ad, *a)
+}
+
+// WriteTo will write the snapshot to w.
+func (w *BinarySnapshotWriter) WriteTo(out io.Writer) (int64, error) {
+	aaKeys, aaPerm, err := binarySortIDs(len(w.aa), func(i int) string { return w.aa[i].ID })
+	if err != nil {
+		return 0, err
+	}
+	adKeys, adPerm, err := binarySortIDs(len(w.ad), func(i int) string { return w.ad[i].ID })
+	if err != nil {
+		return 0, err
+	}
+	aaRow := make(map[string]uint32, len(w.aa))
+	for row, i := range aaPerm {
+		aaRow[w.aa[i].ID] = uint32(row)
+	}
+
+	var strs binaryStrings
+	strs.index = map[string]uint32{"": 0}
+	strs.list = []string{""}
+
+	var cols [][]byte
+	col := func(size int) []byte {
+		b := make([]byte, size)
+		cols = append(cols, b)
+		return b
+	}
+	le := binary.LittleEndian
+
+	// Adgangsadresse columns.
+	n := len(w.aa)
+	ids, status := col(16*n), col(4*n)
+	kommunekode, kommunenavn := col(2*n), col(4*n)
+	vejkode, vejnavn := col(2*n), col(4*n)
+	husnr, supbynavn := col(4*n), col(4*n)
+	postnr, postnrnavn := col(2*n), col(4*n)
+	ejerlav, matrikelnr := col(4*n), col(4*n)
+	x, y := col(8*n), col(8*n)
+	for i, j := range aaPerm {
+		a := &w.aa[j]
+		copy(ids[16*i:], aaKeys[j][:])
+		le.PutUint32(status[4*i:], uint32(int32(a.Status)))
+		le.PutUint16(kommunekode[2*i:], binaryCode(a.Kommune.Kode))
+		le.PutUint32(kommunenavn[4*i:], strs.add(a.Kommune.Navn))
+		le.PutUint16(vejkode[2*i:], binaryCode(a.Vejstykke.Kode))
+		le.PutUint32(vejnavn[4*i:], strs.add(a.Vejstykke.Navn))
+		le.PutUint32(husnr[4*i:], strs.add(string(a.Husnr)))
+		le.PutUint32(supbynavn[4*i:], strs.add(a.SupplerendeBynavn))
+		le.PutUint16(postnr[2*i:], binaryCode(a.Postnummer.Nr))
+		le.PutUint32(postnrnavn[4*i:], strs.add(a.Postnummer.Navn))
+		le.PutUint32(ejerlav[4*i:], uint32(a.Ejerlav.Kode))
+		le.PutUint32(matrikelnr[4*i:], strs.add(a.Matrikelnr))
+		px, py := math.NaN(), math.NaN()
+		if k := a.Adgangspunkt.Koordinater; len(k) >= 2 {
+			px, py = k[0], k[1]
+		}
+		le.PutUint64(x[8*i:], math.Float64bits(px))
+		le.PutUint64(y[8*i:], math.Float64bits(py))
+	}
+
+	// Adresse columns.
+	m := len(w.ad)
+	adIDs, adStatus := col(16*m), col(4*m)
+	etage, dør, adAA := col(4*m), col(4*m), col(4*m)
+	for i, j := range adPerm {
+		a := &w.ad[j]
+		copy(adIDs[16*i:], adKeys[j][:])
+		le.PutUint32(adStatus[4*i:], uint32(int32(a.Status)))
+		le.PutUint32(etage[4*i:], strs.add(string(a.Etage)))
+		le.PutUint32(dør[4*i:], strs.add(string(a.Dør)))
+		row, ok := aaRow[a.Adgangsadresse.ID]
+		if !ok {
+			row = math.MaxUint32
+		}
+		le.PutUint32(adAA[4*i:], row)
+	}
+
+	// String table: offsets followed by data.
+	offsets := make([]byte, 4*(len(strs.list)+1))
+	size := 0
+	for i, s := range strs.list {
+		le.PutUint32(offsets[4*i:], uint32(size))
+		size += len(s)
+	}
+	le.PutUint32(offsets[4*len(strs.list):], uint32(size))
+	data := make([]byte, 0, size)
+	for _, s := range strs.list {
+		data = append(data, s...)
+	}
+
+	total := binarySnapshotHeaderSize + len(offsets) + len(data)
+	for _, c := range cols {
+		total += len(c)
+	}
+	hdr := make([]byte, binarySnapshotHeaderSize)
+	copy(hdr, binarySnapshotMagic[:])
+	le.PutUint32(hdr[8:], BinarySnapshotVersion)
+	le.PutUint64(hdr[16:], uint64(time.Now().UnixNano()))
+	le.PutUint64(hdr[24:], uint64(w.Sekvensnummer))
+	le.PutUint32(hdr[32:], uint32(n))
+	le.PutUint32(hdr[36:], uint32(m))
+	le.PutUint32(hdr[40:], uint32(len(strs.list)))
+	le.PutUint64(hdr[48:], uint64(total))
+
+	bw := bufio.NewWriter(out)
+	var written int64
+	for _, b := range append([][]byte{hdr, offsets, data}, cols...) {
+		k, err := bw.Write(b)
+		written += int64(k)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, bw.Flush()
+}
+
+// BinarySnapshot is a snapshot of adgangsadresser and adresser written by a BinarySnapshotWriter.
+//
+// Objects are read directly from the snapshot data when requested,
+// so opening a snapshot is fast, no matter its size.
+// Adgangsadresser and adresser are ordered by ID.
+//
+// A BinarySnapshot is safe for concurrent use.
+type BinarySnapshot struct {
+	data    []byte
+	closeFn func() error
+
+	version       int
+	created       time.Time
+	sekvensnummer int64
+	nAA, nAd      int
+
+	strOffsets []byte
+	strData    []byte
+
+	// Adgangsadresse columns
+	aaID, aaStatus, kommunekode, kommunenavn, vejkode, vejnavn []byte
+	husnr, supbynavn, postnr, postnrnavn, ejerlav, matrikelnr  []byte
+	x, y                                                       []byte
+
+	// Adresse columns
+	adID, adStatus, etage, dør, adAA []byte
+}
+
+// OpenBinarySnapshot opens a binary snapshot file.
+// Where supported, the file is memory mapped, otherwise it is read into memory.
+// Close must be called when the snapshot is no longer used.
+func OpenBinarySnapshot(path string) (*BinarySnapshot, error) {
+	data, closeFn, err := mmapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := ReadBinarySnapshot(data)
+	if err != nil {
+		closeFn()
+		return nil, err
+	}
+	s.closeFn = closeFn
+	return s, nil
+}
+
+// ReadBinarySnapshot reads a binary snapshot from memory.
+// The data must not be modified while the snapshot is in use.
+func ReadBinarySnapshot(data []byte) (*BinarySnapshot, error) {
+	le := binary.LittleEndian
+	if len(data) < binarySnapshotHeaderSize || string(data[:8]) != string(binarySnapshotMagic[:]) {
+		return nil, ErrBinarySnapshotFormat
+	}
+	s := &BinarySnapshot{data: data}
+	s.version = int(le.Uint32(data[8:]))
+	if s.version != BinarySnapshotVersion {
+		return nil, ErrBinarySnapshotVersion
+	}
+	if le.Uint64(data[48:]) != uint64(len(data)) {
+		return nil, ErrBinarySnapshotFormat
+	}
+	s.created = time.Unix(0, int64(le.Uint64(data[16:])))
+	s.sekvensnummer = int64(le.Uint64(data[24:]))
+	s.nAA = int(le.Uint32(data[32:]))
+	s.nAd = int(le.Uint32(data[36:]))
+	nStr := int(le.Uint32(data[40:]))
+
+	rest := data[binarySnapshotHeaderSize:]
+	next := func(size int) []byte {
+		if size > len(rest) {
+			return nil
+		}
+		b := rest[:size:size]
+		rest = rest[size:]
+		return b
+	}
+	s.strOffsets = next(4 * (nStr + 1))
+	if s.strOffsets == nil {
+		return nil, ErrBinarySnapshotFormat
+	}
+	s.strData = next(int(le.Uint32(s.strOffsets[4*nStr:])))
+
+	n, m := s.nAA, s.nAd
+	cols := []struct {
+		dst  *[]byte
+		size int
+	}{
+		{&s.aaID, 16 * n}, {&s.aaStatus, 4 * n},
+		{&s.kommunekode, 2 * n}, {&s.kommunenavn, 4 * n},
+		{&s.vejkode, 2 * n}, {&s.vejnavn, 4 * n},
+		{&s.husnr, 4 * n}, {&s.supbynavn, 4 * n},
+		{&s.postnr, 2 * n}, {&s.postnrnavn, 4 * n},
+		{&s.ejerlav, 4 * n}, {&s.matrikelnr, 4 * n},
+		{&s.x, 8 * n}, {&s.y, 8 * n},
+		{&s.adID, 16 * m}, {&s.adStatus, 4 * m},
+		{&s.etage, 4 * m}, {&s.dør, 4 * m}, {&s.adAA, 4 * m},
+	}
+	for _, c := range cols {
+		*c.dst = next(c.size)
+		if *c.dst == nil {
+			return nil, ErrBinarySnapshotFormat
+		}
+	}
+	if s.strData == nil || len(rest) != 0 {
+		return nil, ErrBinarySnapshotFormat
+	}
+	if !s.valid(nStr) {
+		return nil, ErrBinarySnapshotFormat
+	}
+	return s, nil
+}
+
+// valid checks that the string offsets are increasing and within the string data,
+// and that all string and adgangsadresse references are within their tables,
+// so corrupted data cannot cause out of range reads.
+func (s *BinarySnapshot) valid(nStr int) bool {
+	le := binary.LittleEndian
+	prev := uint32(0)
+	for i := 0; i <= nStr; i++ {
+		off := le.Uint32(s.strOffsets[4*i:])
+		if off < prev || int(off) > len(s.strData) {
+			return false
+		}
+		prev = off
+	}
+	for _, col := range [][]byte{s.kommunenavn, s.vejnavn, s.husnr, s.supbynavn, s.postnrnavn, s.matrikelnr, s.etage, s.dør} {
+		for i := 0; i < len(col); i += 4 {
+			if int(le.Uint32(col[i:])) >= nStr {
+				return false
+			}
+		}
+	}
+	for i := 0; i < len(s.adAA); i += 4 {
+		if row := le.Uint32(s.adAA[i:]); row != math.MaxUint32 && int(row) >= s.nAA {
+			return false
+		}
+	}
+	return true
+}
+
+// Close releases the snapshot data.
+// Objects already returned from the snapshot remain valid.
+func (s *BinarySnapshot) Close() error {
+	if s.closeFn == nil {
+		return nil
+	}
+	err := s.closeFn()
+	s.closeFn = nil
+	return err
+}
+
+// Version returns the format version of the snapshot.
+func (s *BinarySnapshot) Version() int {
+	return s.version
+}
+
+// Created returns the time the snapshot was written.
+func (s *BinarySnapshot) Created() time.Time {
+	return s.created
+}
+
+// Sekvensnummer returns the sekvensnummer of the data in the snapshot,
+// or 0 if it wasn't set when the snapshot was written.
+// Compare it to SenesteSekvensnummer() to check if the snapshot is outdated.
+func (s *BinarySnapshot) Sekvensnummer() int64 {
+	return s.sekvensnummer
+}
+
+// NumAdgangsAdresser returns the number of adgangsadresser in the snapshot.
+func (s *BinarySnapshot) NumAdgangsAdresser() int {
+	return s.nAA
+}
+
+// NumAdresser returns the number of adresser in the snapshot.
+func (s *BinarySnapshot) NumAdresser() int {
+	return s.nAd
+}
+
+// AdgangsAdresse returns adgangsadresse number i, where 0 <= i < NumAdgangsAdresser().
+func (s *BinarySnapshot) AdgangsAdresse(i int) AdgangsAdresse {
+	le := binary.LittleEndian
+	var a AdgangsAdresse
+	a.ID = binaryUUID(s.aaID[16*i:])
+	a.Status = Status(int32(le.Uint32(s.aaStatus[4*i:])))
+	a.Kommune.Kode = binaryCodeString(le.Uint16(s.kommunekode[2*i:]))
+	a.Kommune.Navn = s.str(s.kommunenavn, i)
+	a.Vejstykke.Kode = binaryCodeString(le.Uint16(s.vejkode[2*i:]))
+	a.Vejstykke.Navn = s.str(s.vejnavn, i)
+	a.Husnr = Husnr(s.str(s.husnr, i))
+	a.SupplerendeBynavn = s.str(s.supbynavn, i)
+	a.Postnummer.Nr = binaryCodeString(le.Uint16(s.postnr[2*i:]))
+	a.Postnummer.Navn = s.str(s.postnrnavn, i)
+	a.Ejerlav.Kode = int(le.Uint32(s.ejerlav[4*i:]))
+	a.Matrikelnr = s.str(s.matrikelnr, i)
+	x := math.Float64frombits(le.Uint64(s.x[8*i:]))
+	y := math.Float64frombits(le.Uint64(s.y[8*i:]))
+	if !math.IsNaN(x) && !math.IsNaN(y) {
+		a.Adgangspunkt.Koordinater = []float64{x, y}
+	}
+	return a
+}
+
+// Adresse returns adresse number i, where 0 <= i < NumAdresser().
+// The Adgangsadresse field is filled from the adgangsadresse in the snapshot.
+func (s *BinarySnapshot) Adresse(i int) Adresse {
+	le := binary.LittleEndian
+	var a Adresse
+	a.ID = binaryUUID(s.adID[16*i:])
+	a.Status = Status(int32(le.Uint32(s.adStatus[4*i:])))
+	a.Etage = Etage(s.str(s.etage, i))
+	a.Dør = Dør(s.str(s.dør, i))
+	if row := le.Uint32(s.adAA[4*i:]); row != math.MaxUint32 {
+		a.Adgangsadresse = s.AdgangsAdresse(int(row))
+	}
+	return a
+}
+
+// FindAdgangsAdresse returns the adgangsadresse with the specified ID.
+// nil is returned if it is not in the snapshot.
+func (s *BinarySnapshot) FindAdgangsAdresse(id string) *AdgangsAdresse {
+	i, ok := binaryFind(s.aaID, s.nAA, id)
+	if !ok {
+		return nil
+	}
+	a := s.AdgangsAdresse(i)
+	return &a
+}
+
+// FindAdresse returns the adresse with the specified ID.
+// nil is returned if it is not in the snapshot.
+func (s *BinarySnapshot) FindAdresse(id string) *Adresse {
+	i, ok := binaryFind(s.adID, s.nAd, id)
+	if !ok {
+		return nil
+	}
+	a := s.Adresse(i)
+	return &a
+}
+
+// AdgangsAdresser returns an iterator of all adgangsadresser in the snapshot.
+func (s *BinarySnapshot) AdgangsAdresser() *AdgangsAdresseIter {
+	ret := &AdgangsAdresseIter{a: make(chan AdgangsAdresse, 100)}
+	go func() {
+		defer close(ret.a)
+		for i := 0; i < s.nAA; i++ {
+			ret.a <- s.AdgangsAdresse(i)
+		}
+		ret.err = io.EOF
+	}()
+	return ret
+}
+
+// Adresser returns an iterator of all adresser in the snapshot.
+func (s *BinarySnapshot) Adresser() *AdresseIter {
+	ret := &AdresseIter{a: make(chan Adresse, 100)}
+	go func() {
+		defer close(ret.a)
+		for i := 0; i < s.nAd; i++ {
+			ret.a <- s.Adresse(i)
+		}
+		ret.err = io.EOF
+	}()
+	return ret
+}
+
+// str returns the string referenced at row i of the column.
+func (s *BinarySnapshot) str(col []byte, i int) string {
+	le := binary.LittleEndian
+	idx := le.Uint32(col[4*i:])
+	start, end := le.Uint32(s.strOffsets[4*idx:]), le.Uint32(s.strOffsets[4*idx+4:])
+	return string(s.strData[start:end])
+}
+
+// binaryStrings is a deduplicated string table.
+type binaryStrings struct {
+	index map[string]uint32
+	list  []string
+}
+
+func (b *binaryStrings) add(s string) uint32 {
+	if i, ok := b.index[s]; ok {
+		return i
+	}
+	i := uint32(len(b.list))
+	b.index[s] = i
+	b.list = append(b.list, s)
+	return i
+}
+
+// binaryCode converts a code like "0101" to a number. Empty or invalid codes are stored as 0.
+func binaryCode(s string) uint16 {
+	v, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0
+	}
+	return uint16(v)
+}
+
+// binaryCodeString converts a number to a 4 digit code. 0 is returned as an empty string.
+func binaryCodeString(v uint16) string {
+	if v == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%04d", v)
+}
+
+// binaryPutUUID writes the UUID as 16 bytes to dst.
+func binaryPutUUID(dst []byte, id string) error {
+	if len(id) != 36 || id[8] != '-' || id[13] != '-' || id[18] != '-' || id[23] != '-' {
+		return fmt.Errorf("binary snapshot: invalid id '%s'", id)
+	}
+	h := id[0:8] + id[9:13] + id[14:18] + id[19:23] + id[24:36]
+	_, err := hex.Decode(dst[:16], []byte(h))
+	if err != nil {
+		return fmt.Errorf("binary snapshot: invalid id '%s'", id)
+	}
+	return nil
+}
+
+// binaryUUID returns the UUID stored in the first 16 bytes of b.
+func binaryUUID(b []byte) string {
+	h := hex.EncodeToString(b[:16])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// binaryFind returns the row of the id in a sorted column of n UUIDs.
+func binaryFind(col []byte, n int, id string) (int, bool) {
+	var want [16]byte
+	if binaryPutUUID(want[:], id) != nil {
+		return 0, false
+	}
+	i := sort.Search(n, func(i int) bool {
+		return string(col[16*i:16*i+16]) >= string(want[:])
+	})
+	if i < n && string(col[16*i:16*i+16]) == string(want[:]) {
+		return i, true
+	}
+	return 0, false
+}
+
+// binarySortIDs converts n UUIDs returned by id to binary form,
+// and returns them with the order of the indexes sorted by ID.
+func binarySortIDs(n int, id func(i int) string) ([][16]byte, []int, error) {
+	keys := make([][16]byte, n)
+	perm := make([]int, n)
+	for i := range keys {
+		if err := binaryPutUUID(keys[i][:], id(i)); err != nil {
+			return nil, nil, err
+		}
+		perm[i] = i
+	}
+	sort.Sort(binaryIDOrder{keys: keys, perm: perm})
+	return keys, perm, nil
+}
+
+type binaryIDOrder struct {
+	keys [][16]byte
+	perm []int
+}
+
+func (b binaryIDOrder) Len() int      { return len(b.perm) }
+func (b binaryIDOrder) Swap(i, j int) { b.perm[i], b.perm[j] = b.perm[j], b.perm[i] }
+func (b binaryIDOrder) Less(i, j int) bool {
+	return string(b.keys[b.perm[i]][:]) < string(b.keys[b.perm[j]][:])
+}
@@ -0,0 +1,159 @@
+package dawa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var binsnapshot_adresse_input = `[
+{"id":"0a3f50a0-75dc-32b8-e044-0003ba298018","status":1,"etage":"1","dør":"tv","adgangsadresse":{"id":"0a3f5081-c65d-32b8-e044-0003ba298018","status":1,"husnr":"46","kommune":{"kode":"0101","navn":"København"},"vejstykke":{"kode":"6100","navn":"Rødkildevej"},"postnummer":{"nr":"2400","navn":"København NV"},"ejerlav":{"kode":2000175},"matrikelnr":"1a","adgangspunkt":{"koordinater":[12.5,55.7]}}},
+{"id":"0a3f509f-75dc-32b8-e044-0003ba298018","status":3,"etage":"2","dør":"tv","adgangsadresse":{"id":"0a3f5081-c65d-32b8-e044-0003ba298018","status":1,"husnr":"46","kommune":{"kode":"0101","navn":"København"},"vejstykke":{"kode":"6100","navn":"Rødkildevej"},"postnummer":{"nr":"2400","navn":"København NV"},"ejerlav":{"kode":2000175},"matrikelnr":"1a","adgangspunkt":{"koordinater":[12.5,55.7]}}}
+]`
+
+func TestBinarySnapshot(t *testing.T) {
+	iter, err := ImportAdresserJSON(bytes.NewBufferString(binsnapshot_adresse_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewBinarySnapshotWriter()
+	w.Sekvensnummer = 1234
+	err = w.LoadAdresser(iter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Status values outside the known codes are kept, like the CSV sentinel -1.
+	w.AddAdgangsAdresse(&AdgangsAdresse{ID: "0A3F5082-C65D-32B8-E044-0003BA298018", Husnr: "48", Status: -1})
+	// Adding an adresse again replaces it.
+	w.AddAdresse(&Adresse{ID: "0a3f509f-75dc-32b8-e044-0003ba298018", Status: 300, Etage: "2", Dør: "th", Adgangsadresse: AdgangsAdresse{ID: "0a3f5081-c65d-32b8-e044-0003ba298018"}})
+
+	dir, err := ioutil.TempDir("", "dawa-binsnapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "snapshot.bin")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = w.WriteTo(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	s, err := OpenBinarySnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if s.Version() != BinarySnapshotVersion || s.Sekvensnummer() != 1234 || s.Created().IsZero() {
+		t.Fatalf("unexpected header: %d, %d, %v", s.Version(), s.Sekvensnummer(), s.Created())
+	}
+	if s.NumAdgangsAdresser() != 2 || s.NumAdresser() != 2 {
+		t.Fatalf("unexpected counts: %d, %d", s.NumAdgangsAdresser(), s.NumAdresser())
+	}
+
+	// Adresser are ordered by ID.
+	a := s.Adresse(0)
+	if a.ID != "0a3f509f-75dc-32b8-e044-0003ba298018" || a.Status != 300 || a.Etage != "2" || a.Dør != "th" {
+		t.Fatalf("unexpected adresse: %#v", a)
+	}
+	expect := AdgangsAdresse{
+		ID:         "0a3f5081-c65d-32b8-e044-0003ba298018",
+		Status:     1,
+		Husnr:      "46",
+		Kommune:    KommuneRef{Kode: "0101", Navn: "København"},
+		Vejstykke:  VejstykkeRef{Kode: "6100", Navn: "Rødkildevej"},
+		Postnummer: PostnummerRef{Nr: "2400", Navn: "København NV"},
+		Ejerlav:    Ejerlav{Kode: 2000175},
+		Matrikelnr: "1a",
+	}
+	expect.Adgangspunkt.Koordinater = []float64{12.5, 55.7}
+	if !reflect.DeepEqual(a.Adgangsadresse, expect) {
+		t.Fatalf("unexpected adgangsadresse:\n%#v\nexpected:\n%#v", a.Adgangsadresse, expect)
+	}
+
+	if aa := s.FindAdgangsAdresse("0a3f5082-c65d-32b8-e044-0003ba298018"); aa == nil || aa.Husnr != "48" || aa.Status != -1 || aa.Adgangspunkt.Koordinater != nil {
+		t.Fatalf("unexpected adgangsadresse: %#v", aa)
+	}
+	if ad := s.FindAdresse("0a3f50a0-75dc-32b8-e044-0003ba298018"); ad == nil || ad.Etage != "1" || ad.Adgangsadresse.Husnr != "46" {
+		t.Fatalf("unexpected adresse: %#v", ad)
+	}
+	if s.FindAdresse("0a3f50a1-75dc-32b8-e044-0003ba298018") != nil || s.FindAdresse("invalid") != nil {
+		t.Fatal("expected nil for unknown adresse")
+	}
+
+	// The iterators can be used to load other structures.
+	ix := NewIndex()
+	if err = ix.LoadAdresser(s.Adresser()); err != nil {
+		t.Fatal(err)
+	}
+	if err = ix.LoadAdgangsAdresser(s.AdgangsAdresser()); err != nil {
+		t.Fatal(err)
+	}
+	if n, m := ix.Len(); n != 2 || m != 2 {
+		t.Fatalf("unexpected index size: %d, %d", n, m)
+	}
+}
+
+func TestBinarySnapshotInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewBinarySnapshotWriter()
+	_, err := w.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	if _, err = ReadBinarySnapshot(b); err != nil {
+		t.Fatalf("empty snapshot: %v", err)
+	}
+	if _, err = ReadBinarySnapshot(b[:len(b)-1]); err != ErrBinarySnapshotFormat {
+		t.Fatalf("expected ErrBinarySnapshotFormat on truncated data, got %v", err)
+	}
+	if _, err = ReadBinarySnapshot([]byte("not a snapshot")); err != ErrBinarySnapshotFormat {
+		t.Fatalf("expected ErrBinarySnapshotFormat, got %v", err)
+	}
+	old := append([]byte{}, b...)
+	binary.LittleEndian.PutUint32(old[8:], BinarySnapshotVersion+1)
+	if _, err = ReadBinarySnapshot(old); err != ErrBinarySnapshotVersion {
+		t.Fatalf("expected ErrBinarySnapshotVersion, got %v", err)
+	}
+
+	// Corrupted body with references outside the tables.
+	iter, err := ImportAdresserJSON(bytes.NewBufferString(binsnapshot_adresse_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w = NewBinarySnapshotWriter()
+	if err = w.LoadAdresser(iter); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if _, err = w.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	b = buf.Bytes()
+	corrupt := map[string]func(c []byte){
+		"string offset":  func(c []byte) { binary.LittleEndian.PutUint32(c[binarySnapshotHeaderSize+4:], 1<<30) },
+		"string index":   func(c []byte) { binary.LittleEndian.PutUint32(c[len(c)-12:], 1<<30) },
+		"adgangsadresse": func(c []byte) { binary.LittleEndian.PutUint32(c[len(c)-4:], 5) },
+	}
+	for name, fn := range corrupt {
+		c := append([]byte{}, b...)
+		fn(c)
+		if _, err = ReadBinarySnapshot(c); err != ErrBinarySnapshotFormat {
+			t.Errorf("%s: expected ErrBinarySnapshotFormat, got %v", name, err)
+		}
+	}
+
+	w.AddAdgangsAdresse(&AdgangsAdresse{ID: "not-a-uuid"})
+	if _, err = w.WriteTo(&buf); err == nil {
+		t.Fatal("expected error on invalid id")
+	}
+}
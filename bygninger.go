@@ -0,0 +1,123 @@
+package dawa
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/ugorji/go/codec"
+)
+
+// En bygning er en bygningspolygon fra GeoDanmark, som er koblet til bygningen i BBR
+// og til de adgangsadresser, der ligger i bygningen.
+//
+// Bygninger er udstillet under /bygninger
+type Bygning struct {
+	ID              string              `json:"id"`              // Bygningens unikke id i GeoDanmark.
+	Href            string              `json:"href"`            // Bygningens unikke URL.
+	Bygningstype    string              `json:"bygningstype"`    // Bygningens type, f.eks. "Bygning", "Tank/Silo" eller "Drivhus".
+	Metode3D        string              `json:"metode3d"`        // Metode for 3D-registrering, f.eks. "Tag" eller "Terræn".
+	Målested        string              `json:"målested"`        // Hvor på bygningen er målt, f.eks. "Tagfod" eller "Tagflade".
+	BBRBygningID    string              `json:"bbrbygning_id"`   // Id på den tilknyttede bygning i BBR.
+	Synlig          bool                `json:"synlig"`          // Angiver om bygningen er synlig på luftfoto.
+	Overlap         bool                `json:"overlap"`         // Angiver om bygningen overlapper en anden bygning.
+	Adgangsadresser []AdgangsAdresseRef `json:"adgangsadresser"` // Adgangsadresserne som ligger i bygningen.
+	Kommuner        []KommuneRef        `json:"kommuner"`        // Kommunerne som bygningen er beliggende i.
+	Bbox            []float64           `json:"bbox"`            // Bygningens bounding box som [minx, miny, maxx, maxy].
+	VisueltCenter   []float64           `json:"visueltcenter"`   // Et punkt inden for bygningen, som egner sig til visning på kort, som [x,y].
+	ChangeInfo
+	Geometri MultiPolygon `json:"geometri,omitempty"` // Bygningens omrids. Kun udfyldt ved import fra GeoJSON.
+}
+
+// BygningIter is an Iterator that enable you to get individual entries.
+type BygningIter struct {
+	a   chan Bygning
+	err error
+	validating
+	closer
+}
+
+// Next will return the next item.
+// It will return an error if that has been encountered.
+// When there are not more entries nil, io.EOF will be returned.
+func (a *BygningIter) Next() (*Bygning, error) {
+	for {
+		v, ok := <-a.a
+		if !ok {
+			return nil, a.err
+		}
+		if !a.skip(&v) {
+			return &v, nil
+		}
+	}
+}
+
+// ValidateTo will make the iterator validate each record with its Validate method.
+// Invalid records are sent to the sink and skipped, instead of aborting the import.
+// It must be called before the first call to Next.
+func (a *BygningIter) ValidateTo(sink ErrorSink) *BygningIter {
+	a.sink = sink
+	return a
+}
+
+// ImportBygningerJSON will import "bygninger" from a JSON input, supplied to the reader.
+// An iterator will be returned that return all items.
+//
+// Note that the JSON format does not contain the geometry. Use ImportBygningerGeoJSON to get that.
+func ImportBygningerJSON(in io.Reader) (*BygningIter, error) {
+	var h codec.JsonHandle
+	h.DecodeOptions.ErrorIfNoField = JSONStrictFieldCheck
+	// use a buffered reader for efficiency
+	if _, ok := in.(io.ByteScanner); !ok {
+		in = bufio.NewReader(in)
+	}
+	ret := &BygningIter{a: make(chan Bygning, 100)}
+	go func() {
+		defer close(ret.a)
+		var dec *codec.Decoder = codec.NewDecoder(in, &h)
+		ret.err = dec.Decode(&ret.a)
+		if ret.err == nil {
+			ret.err = io.EOF
+		}
+	}()
+
+	return ret, nil
+}
+
+// ImportBygningerGeoJSON will import "bygninger" from a GeoJSON FeatureCollection, supplied to the reader.
+// An iterator will be returned that return all items, including the geometry of each item.
+//
+// The GeoJSON properties are flat, so the adgangsadresser and kommuner of each bygning are not filled.
+func ImportBygningerGeoJSON(in io.Reader) (*BygningIter, error) {
+	ret := &BygningIter{a: make(chan Bygning, 100)}
+	go func() {
+		defer close(ret.a)
+		ret.err = decodeGeoJSONFeatures(in, func(f geoJSONFeature) {
+			v := f.Properties
+			a := Bygning{}
+			a.ID = v.String("id")
+			a.Href = v.String("href")
+			a.Bygningstype = v.String("bygningstype")
+			a.Metode3D = v.String("metode3d")
+			a.Målested = v.String("målested")
+			a.BBRBygningID = v.String("bbrbygning_id")
+			a.Synlig = v.Bool("synlig")
+			a.Overlap = v.Bool("overlap")
+			a.Ændret = v.String("ændret")
+			a.GeoÆndret = v.String("geo_ændret")
+			a.GeoVersion = v.Float("geo_version")
+			a.Geometri = f.Geometry
+			if len(f.Geometry) > 0 {
+				minX, minY, maxX, maxY := f.Geometry.Bounds()
+				a.Bbox = []float64{minX, minY, maxX, maxY}
+			}
+			if _, ok := v["visueltcenter_x"]; ok {
+				a.VisueltCenter = []float64{v.Float("visueltcenter_x"), v.Float("visueltcenter_y")}
+			}
+			ret.a <- a
+		})
+		if ret.err == nil {
+			ret.err = io.EOF
+		}
+	}()
+	return ret, nil
+}
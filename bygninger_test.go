@@ -0,0 +1,159 @@
+package dawa
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var bygninger_json_input = `
+[
+{
+  "id": "1034",
+  "href": "http://dawa.aws.dk/bygninger/1034",
+  "bygningstype": "Bygning",
+  "metode3d": "Tag",
+  "målested": "Tagfod",
+  "bbrbygning_id": "2b6b3bb7-9c6e-4c76-9f3c-5a0f5a4c3e3a",
+  "synlig": true,
+  "overlap": false,
+  "adgangsadresser": [
+    {
+      "href": "http://dawa.aws.dk/adgangsadresser/0a3f507a-3669-32b8-e044-0003ba298018",
+      "id": "0a3f507a-3669-32b8-e044-0003ba298018"
+    }
+  ],
+  "kommuner": [
+    {
+      "href": "http://dawa.aws.dk/kommuner/101",
+      "kode": "0101",
+      "navn": "København"
+    }
+  ],
+  "bbox": [12.558, 55.672, 12.559, 55.673],
+  "visueltcenter": [12.5585, 55.6725],
+  "ændret": "2018-05-03T14:08:02.125Z",
+  "geo_ændret": "2018-05-03T14:08:02.125Z",
+  "geo_version": 2
+}
+]
+`
+
+var bygninger_geojson_input = `
+{"type":"FeatureCollection","features":[
+{"type":"Feature",
+ "geometry":{"type":"MultiPolygon","coordinates":[[[[12.558,55.672],[12.559,55.672],[12.559,55.673],[12.558,55.672]]]]},
+ "properties":{"id":"1034","bygningstype":"Bygning","synlig":true,"overlap":false,"bbrbygning_id":"2b6b3bb7-9c6e-4c76-9f3c-5a0f5a4c3e3a","geo_version":2}}
+]}
+`
+
+func TestImportBygningerJSON(t *testing.T) {
+	var json_expect = []Bygning{
+		Bygning{
+			ID:              "1034",
+			Href:            "http://dawa.aws.dk/bygninger/1034",
+			Bygningstype:    "Bygning",
+			Metode3D:        "Tag",
+			Målested:        "Tagfod",
+			BBRBygningID:    "2b6b3bb7-9c6e-4c76-9f3c-5a0f5a4c3e3a",
+			Synlig:          true,
+			Adgangsadresser: []AdgangsAdresseRef{AdgangsAdresseRef{Href: "http://dawa.aws.dk/adgangsadresser/0a3f507a-3669-32b8-e044-0003ba298018", ID: "0a3f507a-3669-32b8-e044-0003ba298018"}},
+			Kommuner:        []KommuneRef{KommuneRef{Href: "http://dawa.aws.dk/kommuner/101", Kode: "0101", Navn: "København"}},
+			Bbox:            []float64{12.558, 55.672, 12.559, 55.673},
+			VisueltCenter:   []float64{12.5585, 55.6725},
+			ChangeInfo:      ChangeInfo{Ændret: "2018-05-03T14:08:02.125Z", GeoÆndret: "2018-05-03T14:08:02.125Z", GeoVersion: 2},
+		},
+	}
+
+	iter, err := ImportBygningerJSON(bytes.NewBufferString(bygninger_json_input))
+	if err != nil {
+		t.Fatalf("ImportBygningerJSON: %v", err)
+	}
+	for _, expect := range json_expect {
+		item, err := iter.Next()
+		if err != nil {
+			t.Fatalf("ImportBygningerJSON, iter.Next(): %v", err)
+		}
+		if !reflect.DeepEqual(*item, expect) {
+			t.Fatalf("ImportBygningerJSON, value mismatch.\nGot:\n%#v\nExpected:\n%#v\n", *item, expect)
+		}
+	}
+	_, err = iter.Next()
+	if err != io.EOF {
+		t.Fatalf("ImportBygningerJSON: Expected io.EOF, got:%v", err)
+	}
+}
+
+func TestImportBygningerGeoJSON(t *testing.T) {
+	iter, err := ImportBygningerGeoJSON(strings.NewReader(bygninger_geojson_input))
+	if err != nil {
+		t.Fatalf("ImportBygningerGeoJSON: %v", err)
+	}
+	item, err := iter.Next()
+	if err != nil {
+		t.Fatalf("ImportBygningerGeoJSON, iter.Next(): %v", err)
+	}
+	if item.ID != "1034" || !item.Synlig || item.GeoVersion != 2 {
+		t.Fatalf("ImportBygningerGeoJSON, unexpected properties: %#v", *item)
+	}
+	if len(item.Geometri) != 1 || len(item.Geometri[0][0]) != 4 {
+		t.Fatalf("ImportBygningerGeoJSON, unexpected geometry: %#v", item.Geometri)
+	}
+	if !reflect.DeepEqual(item.Bbox, []float64{12.558, 55.672, 12.559, 55.673}) {
+		t.Fatalf("ImportBygningerGeoJSON, unexpected bbox: %v", item.Bbox)
+	}
+	_, err = iter.Next()
+	if err != io.EOF {
+		t.Fatalf("ImportBygningerGeoJSON: Expected io.EOF, got:%v", err)
+	}
+}
+
+func TestBygningQueryIterGeometri(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bygninger" || r.URL.Query().Get("format") != "geojson" || r.URL.Query().Get("kommunekode") != "0101" {
+			http.Error(w, "unexpected request "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(bygninger_geojson_input))
+	}))
+	defer ts.Close()
+
+	q := NewBygningQuery().Kommunekode("0101")
+	q.host = ts.URL
+	before := q.URL()
+	for i := 0; i < 2; i++ {
+		iter, err := q.IterGeometri()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := iter.Next(); err != nil {
+			t.Fatal(err)
+		}
+		iter.Close()
+		// The query of the caller is not modified.
+		if q.URL() != before {
+			t.Fatalf("query was modified: %s, was %s", q.URL(), before)
+		}
+	}
+}
+
+var BygningURL = []qb{
+	qb{NewBygningQuery().URL(), DefaultHost + "/bygninger"},
+	qb{NewBygningQuery().AdgangsadresseID(multiParam...).URL(), DefaultHost + "/bygninger?adgangsadresseid=" + multiEncoded + ""},
+	qb{NewBygningQuery().BBRBygningID(multiParam...).URL(), DefaultHost + "/bygninger?bbrbygning_id=" + multiEncoded + ""},
+	qb{NewBygningQuery().Polygon(singleParam).URL(), DefaultHost + "/bygninger?polygon=" + singleEncoded + ""},
+	qb{NewBygningQuery().Cirkel(singleParam).URL(), DefaultHost + "/bygninger?cirkel=" + singleEncoded + ""},
+	qb{NewBygningQuery().Punkt(12.5, 55.25).Srid("4326").URL(), DefaultHost + "/bygninger?x=12.5&y=55.25&srid=4326"},
+}
+
+func TestBygningQueryURL(t *testing.T) {
+	for _, q := range BygningURL {
+		if q.Got != q.Expected {
+			t.Fatalf("Unexpected value of parameter:\n     Was:\t%s\nExpected:\t%s", q.Got, q.Expected)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package dawa
+
+import (
+	"io"
+	"strconv"
+)
+
+// BygningQuery is a new query for 'bygning' objects for searching DAWA.
+// Use NewBygningQuery() to get an initialized object.
+// Example:
+//			// Find the buildings of an adgangsadresse
+//			items, err := dawa.NewBygningQuery().AdgangsadresseID("0a3f507a-3669-32b8-e044-0003ba298018").All()
+//
+//			// If err is nil, we go a result
+//			if err == nil {
+//				fmt.Printf("Got items:%+v\n", items)
+//			}
+type BygningQuery struct {
+	queryGeoJSON
+}
+
+// NewBygningQuery returns a new query for 'bygning' objects for searching DAWA.
+//
+// See documentation at http://dawa.aws.dk/bygningerdok
+func NewBygningQuery() *BygningQuery {
+	return &BygningQuery{queryGeoJSON: queryGeoJSON{query: query{host: DefaultHost, path: "/bygninger"}}}
+}
+
+// GetBygningID will return a single Bygning with the specified ID.
+// Will return (nil, io.EOF) if there is no results.
+func GetBygningID(id string) (*Bygning, error) {
+	return NewBygningQuery().ID(id).First()
+}
+
+// Bygninger will return the bygninger that the adgangsadresse is placed in.
+// Uses the ID field.
+func (a AdgangsAdresse) Bygninger() ([]Bygning, error) {
+	return NewBygningQuery().AdgangsadresseID(a.ID).All()
+}
+
+// Iter will return an iterator that allows you to read the results
+// one by one.
+//
+// The results will not contain the geometry of the bygninger. Use IterGeometri() for that.
+func (q BygningQuery) Iter() (*BygningIter, error) {
+	resp, err := q.NoFormat().Request()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := ImportBygningerJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+	iter.AddCloser(resp)
+	return iter, nil
+}
+
+// IterGeometri will return an iterator that allows you to read the results
+// one by one. The results are requested as GeoJSON, so the geometry of each item is included.
+func (q BygningQuery) IterGeometri() (*BygningIter, error) {
+	c := q
+	c.query = q.query.clone()
+	c.Add("format", "geojson")
+	resp, err := c.NoFormat().Request()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := ImportBygningerGeoJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+	iter.AddCloser(resp)
+	return iter, nil
+}
+
+// All returns all results as an array.
+func (q BygningQuery) All() ([]Bygning, error) {
+	resp, err := q.NoFormat().Request()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	ret := make([]Bygning, 0)
+	iter, err := ImportBygningerJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		a, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if a != nil {
+			ret = append(ret, *a)
+		}
+	}
+	return ret, nil
+}
+
+// First will return the first result from a query.
+// Note the entire query is executed, so only use this if you expect a few results.
+//
+// Will return (nil, io.EOF) if there is no results.
+func (q BygningQuery) First() (*Bygning, error) {
+	resp, err := q.NoFormat().Request()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	iter, err := ImportBygningerJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := iter.Next()
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// ID will add a parameter for 'id' to the BygningQuery.
+//
+// Bygningens unikke id. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/bygningerdok
+func (q *BygningQuery) ID(s ...string) *BygningQuery {
+	q.add(&textQuery{Name: "id", Values: s, Multi: true})
+	return q
+}
+
+// Bygningstype will add a parameter for 'bygningstype' to the BygningQuery.
+//
+// Bygningens type, f.eks. "Bygning". (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/bygningerdok
+func (q *BygningQuery) Bygningstype(s ...string) *BygningQuery {
+	q.add(&textQuery{Name: "bygningstype", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// BBRBygningID will add a parameter for 'bbrbygning_id' to the BygningQuery.
+//
+// Id på den tilknyttede bygning i BBR. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/bygningerdok
+func (q *BygningQuery) BBRBygningID(s ...string) *BygningQuery {
+	q.add(&textQuery{Name: "bbrbygning_id", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// AdgangsadresseID will add a parameter for 'adgangsadresseid' to the BygningQuery.
+//
+// Find de bygninger, som den angivne adgangsadresse ligger i. UUID. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/bygningerdok
+func (q *BygningQuery) AdgangsadresseID(s ...string) *BygningQuery {
+	q.add(&textQuery{Name: "adgangsadresseid", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Kommunekode will add a parameter for 'kommunekode' to the BygningQuery.
+//
+// Find de bygninger, som ligger i kommunen angivet ved kommunekoden. 4 cifre. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/bygningerdok
+func (q *BygningQuery) Kommunekode(s ...string) *BygningQuery {
+	q.add(&textQuery{Name: "kommunekode", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Srid will add a parameter for 'srid' to the BygningQuery.
+//
+// Angiver SRID for det koordinatsystem, som geospatiale parametre er angivet i. Default er 4326 (WGS84).
+//
+// See documentation at http://dawa.aws.dk/bygningerdok
+func (q *BygningQuery) Srid(s string) *BygningQuery {
+	q.add(&textQuery{Name: "srid", Values: []string{s}, Multi: false, Null: false})
+	return q
+}
+
+// Polygon will add a parameter for 'polygon' to the BygningQuery.
+//
+// Find de bygninger, som overlapper det angivne polygon.
+// Polygonet specificeres som et array af koordinater på samme måde som koordinaterne
+// specificeres i GeoJSON's polygon.
+// Bemærk at polygoner skal være lukkede, dvs. at første og sidste koordinat skal være identisk.
+// Eksempel: Polygon("[[[10.3,55.3],[10.4,55.3],[10.4,55.31],[10.4,55.31],[10.3,55.3]]]")
+//
+// See documentation at http://dawa.aws.dk/bygningerdok
+func (q *BygningQuery) Polygon(s string) *BygningQuery {
+	q.add(&textQuery{Name: "polygon", Values: []string{s}, Multi: false, Null: false})
+	return q
+}
+
+// Cirkel will add a parameter for 'cirkel' to the BygningQuery.
+//
+// Find de bygninger, som overlapper den cirkel angivet af koordinatet (x,y) og radius r.
+// Radius angives i meter. Cirkel("{x},{y},{r}")
+//
+// See documentation at http://dawa.aws.dk/bygningerdok
+func (q *BygningQuery) Cirkel(s string) *BygningQuery {
+	q.add(&textQuery{Name: "cirkel", Values: []string{s}, Multi: false, Null: false})
+	return q
+}
+
+// Punkt will add parameters for 'x' and 'y' to the BygningQuery.
+//
+// Find de bygninger, som indeholder punktet (x,y).
+//
+// See documentation at http://dawa.aws.dk/bygningerdok
+func (q *BygningQuery) Punkt(x, y float64) *BygningQuery {
+	q.add(&textQuery{Name: "x", Values: []string{strconv.FormatFloat(x, 'f', -1, 64)}, Multi: false, Null: false})
+	q.add(&textQuery{Name: "y", Values: []string{strconv.FormatFloat(y, 'f', -1, 64)}, Multi: false, Null: false})
+	return q
+}
+
+// Side will add a parameter for 'side' to the BygningQuery.
+//
+// Angiver hvilken siden som skal leveres. Se Paginering.
+// http://dawa.aws.dk/generelt#paginering
+//
+// See documentation at http://dawa.aws.dk/bygningerdok
+func (q *BygningQuery) Side(i int) *BygningQuery {
+	q.add(&textQuery{Name: "side", Values: []string{strconv.Itoa(i)}, Multi: false, Null: true})
+	return q
+}
+
+// PerSide will add a parameter for 'per_side' to the BygningQuery.
+//
+// Antal resultater per side. Se Paginering.
+// http://dawa.aws.dk/generelt#paginering
+//
+// See documentation at http://dawa.aws.dk/bygningerdok
+func (q *BygningQuery) PerSide(i int) *BygningQuery {
+	q.add(&textQuery{Name: "per_side", Values: []string{strconv.Itoa(i)}, Multi: false, Null: true})
+	return q
+}
+
+// NoFormat will disable extra whitespace. Always enabled when querying
+func (q *BygningQuery) NoFormat() *BygningQuery {
+	q.add(&textQuery{Name: "noformat", Multi: false, Null: true})
+	return q
+}
@@ -21,17 +21,30 @@ type SupplBynavn struct {
 type SupplBynavnIter struct {
 	a   chan SupplBynavn
 	err error
+	validating
 }
 
 // Next will return the next item in the array.
 // It will return an error if that has been encountered.
 // When there are not more entries nil, io.EOF will be returned.
 func (a *SupplBynavnIter) Next() (*SupplBynavn, error) {
-	v, ok := <-a.a
-	if ok {
-		return &v, nil
+	for {
+		v, ok := <-a.a
+		if !ok {
+			return nil, a.err
+		}
+		if !a.skip(&v) {
+			return &v, nil
+		}
 	}
-	return nil, a.err
+}
+
+// ValidateTo will make the iterator validate each record with its Validate method.
+// Invalid records are sent to the sink and skipped, instead of aborting the import.
+// It must be called before the first call to Next.
+func (a *SupplBynavnIter) ValidateTo(sink ErrorSink) *SupplBynavnIter {
+	a.sink = sink
+	return a
 }
 
 // ImportSupplBynavnJSON will import "supplerende bynavne" from a JSON input, supplied to the reader.
@@ -0,0 +1,208 @@
+package dawa
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Cell sizes of Det Danske Kvadratnet, in meters.
+const (
+	DDKN100m = 100
+	DDKN1km  = 1000
+	DDKN10km = 10000
+)
+
+// DDKNCelle is a cell in Det Danske Kvadratnet (DDKN).
+//
+// A cell is identified by its size and the ETRS89/UTM32 coordinate of its south-west corner.
+// The text form contains the size, the northing and the easting divided by the size,
+// for example "100m_61057_4706", "1km_6105_470" and "10km_610_47".
+//
+// DDKNCelle values can be compared and used as map keys.
+type DDKNCelle struct {
+	Størrelse int // Side length in meters. DDKN100m, DDKN1km or DDKN10km.
+	Nord      int // Northing of the south-west corner in meters.
+	Øst       int // Easting of the south-west corner in meters.
+}
+
+// ParseDDKNCelle will parse the text form of a cell, for example "1km_6105_470".
+// The sizes "100m", "1km" and "10km" are supported.
+func ParseDDKNCelle(s string) (DDKNCelle, error) {
+	var c DDKNCelle
+	parts := strings.Split(s, "_")
+	if len(parts) != 3 {
+		return c, fmt.Errorf("invalid ddkn cell '%s'", s)
+	}
+	switch parts[0] {
+	case "100m":
+		c.Størrelse = DDKN100m
+	case "1km":
+		c.Størrelse = DDKN1km
+	case "10km":
+		c.Størrelse = DDKN10km
+	default:
+		return c, fmt.Errorf("unknown ddkn cell size '%s'", parts[0])
+	}
+	n, err := strconv.Atoi(parts[1])
+	if err != nil || n < 0 {
+		return c, fmt.Errorf("invalid ddkn cell '%s'", s)
+	}
+	e, err := strconv.Atoi(parts[2])
+	if err != nil || e < 0 {
+		return c, fmt.Errorf("invalid ddkn cell '%s'", s)
+	}
+	c.Nord, c.Øst = n*c.Størrelse, e*c.Størrelse
+	return c, nil
+}
+
+// DDKNCelleAt returns the cell of the given size containing the point.
+// The point may be given in the same formats as for SpatialIndex.
+// False is returned if the point or size is invalid.
+func DDKNCelleAt(point []float64, størrelse int) (DDKNCelle, bool) {
+	x, y, ok := spatialPoint(point)
+	if !ok || !ddknSize(størrelse) {
+		return DDKNCelle{}, false
+	}
+	s := float64(størrelse)
+	return DDKNCelle{
+		Størrelse: størrelse,
+		Nord:      int(math.Floor(y/s)) * størrelse,
+		Øst:       int(math.Floor(x/s)) * størrelse,
+	}, true
+}
+
+// Celle returns the cell of the given size from the DDKN fields.
+func (d DDKN) Celle(størrelse int) (DDKNCelle, error) {
+	switch størrelse {
+	case DDKN100m:
+		return ParseDDKNCelle(d.M100)
+	case DDKN1km:
+		return ParseDDKNCelle(d.Km1)
+	case DDKN10km:
+		return ParseDDKNCelle(d.Km10)
+	}
+	return DDKNCelle{}, fmt.Errorf("unknown ddkn cell size %d", størrelse)
+}
+
+// Valid returns true if the cell has a supported size and is aligned to it.
+func (c DDKNCelle) Valid() bool {
+	return ddknSize(c.Størrelse) && c.Nord%c.Størrelse == 0 && c.Øst%c.Størrelse == 0
+}
+
+// String returns the text form of the cell, for example "1km_6105_470".
+func (c DDKNCelle) String() string {
+	if !ddknSize(c.Størrelse) {
+		return ""
+	}
+	name := strconv.Itoa(c.Størrelse) + "m"
+	if c.Størrelse >= DDKN1km {
+		name = strconv.Itoa(c.Størrelse/1000) + "km"
+	}
+	return fmt.Sprintf("%s_%d_%d", name, c.Nord/c.Størrelse, c.Øst/c.Størrelse)
+}
+
+// BBox returns the bounding box of the cell in ETRS89/UTM32.
+func (c DDKNCelle) BBox() (minØst, minNord, maxØst, maxNord float64) {
+	return float64(c.Øst), float64(c.Nord), float64(c.Øst + c.Størrelse), float64(c.Nord + c.Størrelse)
+}
+
+// BBoxWGS84 returns the bounding box of the cell in WGS84.
+// Since the cell is not rectangular in WGS84, the returned box contains all four corners.
+func (c DDKNCelle) BBoxWGS84() (minLongitude, minLatitude, maxLongitude, maxLatitude float64) {
+	x0, y0, x1, y1 := c.BBox()
+	minLongitude, minLatitude = math.Inf(1), math.Inf(1)
+	maxLongitude, maxLatitude = math.Inf(-1), math.Inf(-1)
+	for _, p := range [][2]float64{{x0, y0}, {x1, y0}, {x0, y1}, {x1, y1}} {
+		lon, lat := UTM32ToWGS84(p[0], p[1])
+		minLongitude, maxLongitude = math.Min(minLongitude, lon), math.Max(maxLongitude, lon)
+		minLatitude, maxLatitude = math.Min(minLatitude, lat), math.Max(maxLatitude, lat)
+	}
+	return minLongitude, minLatitude, maxLongitude, maxLatitude
+}
+
+// Contains returns true if the point is inside the cell.
+// The point may be given in the same formats as for SpatialIndex.
+func (c DDKNCelle) Contains(point []float64) bool {
+	p, ok := DDKNCelleAt(point, c.Størrelse)
+	return ok && p == c
+}
+
+// Parent returns the cell of the next larger size containing this cell.
+// False is returned for 10km cells.
+func (c DDKNCelle) Parent() (DDKNCelle, bool) {
+	size := c.Størrelse * 10
+	if !ddknSize(size) {
+		return DDKNCelle{}, false
+	}
+	return DDKNCelle{Størrelse: size, Nord: c.Nord - c.Nord%size, Øst: c.Øst - c.Øst%size}, true
+}
+
+// Children returns the 100 cells of the next smaller size contained in this cell,
+// ordered by northing and then easting.
+// nil is returned for 100m cells.
+func (c DDKNCelle) Children() []DDKNCelle {
+	size := c.Størrelse / 10
+	if !ddknSize(size) {
+		return nil
+	}
+	res := make([]DDKNCelle, 0, 100)
+	for n := 0; n < 10; n++ {
+		for e := 0; e < 10; e++ {
+			res = append(res, DDKNCelle{Størrelse: size, Nord: c.Nord + n*size, Øst: c.Øst + e*size})
+		}
+	}
+	return res
+}
+
+// Neighbours returns the 8 cells of the same size surrounding this cell,
+// ordered by northing and then easting.
+func (c DDKNCelle) Neighbours() []DDKNCelle {
+	res := make([]DDKNCelle, 0, 8)
+	for n := -1; n <= 1; n++ {
+		for e := -1; e <= 1; e++ {
+			if n == 0 && e == 0 {
+				continue
+			}
+			res = append(res, DDKNCelle{Størrelse: c.Størrelse, Nord: c.Nord + n*c.Størrelse, Øst: c.Øst + e*c.Størrelse})
+		}
+	}
+	return res
+}
+
+// GroupDDKN will read all adgangsadresser from the iterator and group them by cell of the given size.
+// The iterator is read until io.EOF is returned.
+//
+// The cell is read from the DDKN fields of the adgangsadresse.
+// If they are not set, the cell is calculated from the adgangspunkt.
+// Adgangsadresser without either are skipped.
+func GroupDDKN(iter *AdgangsAdresseIter, størrelse int) (map[DDKNCelle][]*AdgangsAdresse, error) {
+	if !ddknSize(størrelse) {
+		return nil, fmt.Errorf("unknown ddkn cell size %d", størrelse)
+	}
+	res := make(map[DDKNCelle][]*AdgangsAdresse)
+	for {
+		a, err := iter.Next()
+		if err == io.EOF {
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		c, err := a.DDKN.Celle(størrelse)
+		if err != nil {
+			var ok bool
+			c, ok = DDKNCelleAt(a.Adgangspunkt.Koordinater, størrelse)
+			if !ok {
+				continue
+			}
+		}
+		res[c] = append(res[c], a)
+	}
+}
+
+func ddknSize(s int) bool {
+	return s == DDKN100m || s == DDKN1km || s == DDKN10km
+}
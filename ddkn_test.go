@@ -0,0 +1,101 @@
+package dawa
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseDDKNCelle(t *testing.T) {
+	c, err := ParseDDKNCelle("100m_61753_7237")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c != (DDKNCelle{Størrelse: DDKN100m, Nord: 6175300, Øst: 723700}) || !c.Valid() {
+		t.Fatalf("unexpected cell %#v", c)
+	}
+	for _, s := range []string{"100m_61753_7237", "1km_6175_723", "10km_617_72"} {
+		c, err := ParseDDKNCelle(s)
+		if err != nil || c.String() != s {
+			t.Errorf("%s: got %q, %v", s, c.String(), err)
+		}
+	}
+	for _, s := range []string{"", "250m_1_2", "1km_6175", "1km_x_723", "1km_-1_2"} {
+		if _, err := ParseDDKNCelle(s); err == nil {
+			t.Errorf("ParseDDKNCelle(%q): expected error", s)
+		}
+	}
+
+	// Same point as in the CSV import test.
+	p := []float64{55.6720594006065, 12.5582458296225}
+	d := DDKN{Km1: "1km_6175_723", Km10: "10km_617_72", M100: "100m_61753_7237"}
+	for _, size := range []int{DDKN100m, DDKN1km, DDKN10km} {
+		want, err := d.Celle(size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, ok := DDKNCelleAt(p, size); !ok || got != want || !want.Contains(p) {
+			t.Errorf("size %d: got %v, want %v", size, got, want)
+		}
+	}
+}
+
+func TestDDKNCelleGeometry(t *testing.T) {
+	c, _ := ParseDDKNCelle("100m_61753_7237")
+	x0, y0, x1, y1 := c.BBox()
+	if x0 != 723700 || y0 != 6175300 || x1 != 723800 || y1 != 6175400 {
+		t.Fatalf("unexpected bbox %v %v %v %v", x0, y0, x1, y1)
+	}
+	lon0, lat0, lon1, lat1 := c.BBoxWGS84()
+	if !(lon0 < 12.5582 && lon1 > 12.5582 && lat0 < 55.6720 && lat1 > 55.6720) {
+		t.Fatalf("unexpected wgs84 bbox %v %v %v %v", lon0, lat0, lon1, lat1)
+	}
+
+	p, ok := c.Parent()
+	if !ok || p.String() != "1km_6175_723" {
+		t.Fatalf("unexpected parent %v", p)
+	}
+	pp, _ := p.Parent()
+	if pp.String() != "10km_617_72" {
+		t.Fatalf("unexpected parent %v", pp)
+	}
+	if _, ok := pp.Parent(); ok {
+		t.Fatal("expected no parent of 10km cell")
+	}
+	children := p.Children()
+	if len(children) != 100 || children[0].String() != "100m_61750_7230" || children[99].String() != "100m_61759_7239" {
+		t.Fatalf("unexpected children %v", children)
+	}
+	found := false
+	for _, ch := range children {
+		found = found || ch == c
+	}
+	if !found || c.Children() != nil {
+		t.Fatal("unexpected children")
+	}
+	n := c.Neighbours()
+	if len(n) != 8 || n[0].String() != "100m_61752_7236" || n[7].String() != "100m_61754_7238" {
+		t.Fatalf("unexpected neighbours %v", n)
+	}
+}
+
+func TestGroupDDKN(t *testing.T) {
+	iter, err := ImportAdgangsAdresserJSON(bytes.NewBufferString(`[
+{"id":"a","DDKN":{"km1":"1km_6175_723"}},
+{"id":"b","adgangspunkt":{"koordinater":[12.5582458296225,55.6720594006065]}},
+{"id":"c","DDKN":{"km1":"1km_6100_700"}},
+{"id":"d"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := GroupDDKN(iter, DDKN1km)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 {
+		t.Fatalf("expected 2 cells, got %v", res)
+	}
+	c, _ := ParseDDKNCelle("1km_6175_723")
+	if len(res[c]) != 2 || res[c][0].ID != "a" || res[c][1].ID != "b" {
+		t.Fatalf("unexpected group %v", res[c])
+	}
+}
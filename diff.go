@@ -0,0 +1,463 @@
+package dawa
+
+import (
+	"bufio"
+	"container/heap"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/ugorji/go/codec"
+)
+
+// ChangeType is the type of a change found when comparing two sets of addresses.
+type ChangeType int
+
+const (
+	ChangeAdded    ChangeType = iota + 1 // The object is only present in the new set.
+	ChangeRemoved                        // The object is only present in the old set.
+	ChangeModified                       // The object is present in both sets, but one or more fields differ.
+)
+
+// String returns a readable representation of the change type.
+func (c ChangeType) String() string {
+	switch c {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	}
+	return fmt.Sprintf("ChangeType(%d)", int(c))
+}
+
+// FieldDiff is a single field that differs between two versions of an object.
+type FieldDiff struct {
+	Field string      // The name of the field. Fields of nested structs are separated by '.', for example "Vejstykke.Navn".
+	Old   interface{} // The old value.
+	New   interface{} // The new value.
+}
+
+// AdresseChange is a change to a single adresse.
+type AdresseChange struct {
+	Type   ChangeType
+	ID     string      // ID of the adresse.
+	Old    *Adresse    // The old adresse. nil if the adresse was added.
+	New    *Adresse    // The new adresse. nil if the adresse was removed.
+	Fields []FieldDiff // The fields that differ. Only set if Type is ChangeModified.
+}
+
+// AdgangsAdresseChange is a change to a single adgangsadresse.
+type AdgangsAdresseChange struct {
+	Type   ChangeType
+	ID     string          // ID of the adgangsadresse.
+	Old    *AdgangsAdresse // The old adgangsadresse. nil if the adgangsadresse was added.
+	New    *AdgangsAdresse // The new adgangsadresse. nil if the adgangsadresse was removed.
+	Fields []FieldDiff     // The fields that differ. Only set if Type is ChangeModified.
+}
+
+// DiffOptions controls how two sets of addresses are compared.
+type DiffOptions struct {
+	// Sorted indicates that both inputs are sorted by ID.
+	// If an input is found not to be sorted an error is returned.
+	// If false, the inputs will be sorted before they are compared.
+	Sorted bool
+
+	// MaxInMemory is the maximum number of objects of each input that is kept in
+	// memory while sorting. If an input has more objects, it is sorted on disk.
+	// Default is 100000.
+	MaxInMemory int
+
+	// TempDir is the directory used for temporary files when sorting on disk.
+	// Default is the system temporary directory.
+	TempDir string
+}
+
+// ErrNotSorted is returned when inputs are specified as sorted, but they are not.
+var ErrNotSorted = errors.New("diff: input is not sorted by ID")
+
+// DiffAdresser will compare two sets of adresser, and call fn for every adresse that
+// has been added, removed or modified. The changes are delivered in ID order.
+// opt may be nil, in which case default options are used.
+//
+// If fn returns an error, the comparison is stopped and the error is returned.
+// Example:
+//			err := dawa.DiffAdresser(oldIter, newIter, nil, func(c dawa.AdresseChange) error {
+//				fmt.Println(c.Type, c.ID, c.Fields)
+//				return nil
+//			})
+func DiffAdresser(old, new *AdresseIter, opt *DiffOptions, fn func(c AdresseChange) error) error {
+	src := func(iter *AdresseIter) diffSource {
+		return diffSource{
+			next: func() (interface{}, error) {
+				return iter.Next()
+			},
+			id:    func(v interface{}) string { return v.(*Adresse).ID },
+			alloc: func() interface{} { return &Adresse{} },
+		}
+	}
+	return diff(src(old), src(new), opt, func(t ChangeType, a, b interface{}, fields []FieldDiff) error {
+		c := AdresseChange{Type: t, Fields: fields}
+		if a != nil {
+			c.Old = a.(*Adresse)
+			c.ID = c.Old.ID
+		}
+		if b != nil {
+			c.New = b.(*Adresse)
+			c.ID = c.New.ID
+		}
+		return fn(c)
+	})
+}
+
+// DiffAdgangsAdresser will compare two sets of adgangsadresser, and call fn for every adgangsadresse
+// that has been added, removed or modified. The changes are delivered in ID order.
+// opt may be nil, in which case default options are used.
+//
+// If fn returns an error, the comparison is stopped and the error is returned.
+func DiffAdgangsAdresser(old, new *AdgangsAdresseIter, opt *DiffOptions, fn func(c AdgangsAdresseChange) error) error {
+	src := func(iter *AdgangsAdresseIter) diffSource {
+		return diffSource{
+			next: func() (interface{}, error) {
+				return iter.Next()
+			},
+			id:    func(v interface{}) string { return v.(*AdgangsAdresse).ID },
+			alloc: func() interface{} { return &AdgangsAdresse{} },
+		}
+	}
+	return diff(src(old), src(new), opt, func(t ChangeType, a, b interface{}, fields []FieldDiff) error {
+		c := AdgangsAdresseChange{Type: t, Fields: fields}
+		if a != nil {
+			c.Old = a.(*AdgangsAdresse)
+			c.ID = c.Old.ID
+		}
+		if b != nil {
+			c.New = b.(*AdgangsAdresse)
+			c.ID = c.New.ID
+		}
+		return fn(c)
+	})
+}
+
+// diffSource is a stream of pointers to objects with an ID.
+type diffSource struct {
+	next  func() (interface{}, error) // Returns the next object, or io.EOF.
+	id    func(interface{}) string    // Returns the ID of an object.
+	alloc func() interface{}          // Returns a pointer to a new, empty object.
+}
+
+// diff will merge two sorted streams and report differences.
+func diff(a, b diffSource, opt *DiffOptions, fn func(t ChangeType, a, b interface{}, fields []FieldDiff) error) error {
+	o := DiffOptions{}
+	if opt != nil {
+		o = *opt
+	}
+	if o.MaxInMemory <= 0 {
+		o.MaxInMemory = 100000
+	}
+	if o.Sorted {
+		a.next = checkSorted(a)
+		b.next = checkSorted(b)
+	} else {
+		var err error
+		var cleanA, cleanB func()
+		a.next, cleanA, err = sortSource(a, o)
+		if err != nil {
+			return err
+		}
+		defer cleanA()
+		b.next, cleanB, err = sortSource(b, o)
+		if err != nil {
+			return err
+		}
+		defer cleanB()
+	}
+
+	va, err := nextOrNil(a)
+	if err != nil {
+		return err
+	}
+	vb, err := nextOrNil(b)
+	if err != nil {
+		return err
+	}
+	for va != nil || vb != nil {
+		switch {
+		case vb == nil || (va != nil && a.id(va) < b.id(vb)):
+			err = fn(ChangeRemoved, va, nil, nil)
+			if err == nil {
+				va, err = nextOrNil(a)
+			}
+		case va == nil || b.id(vb) < a.id(va):
+			err = fn(ChangeAdded, nil, vb, nil)
+			if err == nil {
+				vb, err = nextOrNil(b)
+			}
+		default:
+			fields := diffFields(reflect.ValueOf(va).Elem(), reflect.ValueOf(vb).Elem(), "", nil)
+			if len(fields) > 0 {
+				err = fn(ChangeModified, va, vb, fields)
+			}
+			if err == nil {
+				va, err = nextOrNil(a)
+			}
+			if err == nil {
+				vb, err = nextOrNil(b)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextOrNil returns the next object of the source, or nil at the end of the stream.
+func nextOrNil(s diffSource) (interface{}, error) {
+	v, err := s.next()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if reflect.ValueOf(v).IsNil() {
+		return nil, nil
+	}
+	return v, nil
+}
+
+// checkSorted returns a next function that returns ErrNotSorted
+// if the IDs of the source are not in ascending order.
+func checkSorted(s diffSource) func() (interface{}, error) {
+	var last string
+	first := true
+	return func() (interface{}, error) {
+		v, err := s.next()
+		if err != nil {
+			return v, err
+		}
+		id := s.id(v)
+		if !first && id < last {
+			return nil, ErrNotSorted
+		}
+		first = false
+		last = id
+		return v, nil
+	}
+}
+
+var awsTimeType = reflect.TypeOf(AwsTime{})
+
+// diffFields will append all fields that differ between a and b to dst.
+// Nested structs are compared field by field.
+func diffFields(a, b reflect.Value, prefix string, dst []FieldDiff) []FieldDiff {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := prefix + f.Name
+		fa, fb := a.Field(i), b.Field(i)
+		switch {
+		case f.Type == awsTimeType:
+			if !fa.Interface().(AwsTime).Time().Equal(fb.Interface().(AwsTime).Time()) {
+				dst = append(dst, FieldDiff{Field: name, Old: fa.Interface(), New: fb.Interface()})
+			}
+		case f.Type.Kind() == reflect.Struct:
+			dst = diffFields(fa, fb, name+".", dst)
+		default:
+			if !reflect.DeepEqual(fa.Interface(), fb.Interface()) {
+				dst = append(dst, FieldDiff{Field: name, Old: fa.Interface(), New: fb.Interface()})
+			}
+		}
+	}
+	return dst
+}
+
+// sortSource returns a next function that returns the objects of the source in ID order.
+// If the source has more than o.MaxInMemory objects, sorted runs are written
+// to temporary files and merged. The returned cleanup function removes the files.
+func sortSource(s diffSource, o DiffOptions) (func() (interface{}, error), func(), error) {
+	var runs []*diffRun
+	cleanup := func() {
+		for _, r := range runs {
+			r.close()
+		}
+	}
+	chunk := make([]interface{}, 0, 1024)
+	for {
+		v, err := nextOrNil(s)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		if v != nil {
+			chunk = append(chunk, v)
+			if len(chunk) < o.MaxInMemory {
+				continue
+			}
+		}
+		// The chunk is full, or we have reached the end of the input.
+		sort.Sort(byDiffID{chunk, s.id})
+		if v == nil && len(runs) == 0 {
+			// Everything fits in memory.
+			return sliceNext(chunk), func() {}, nil
+		}
+		if len(chunk) > 0 {
+			r, err := writeDiffRun(chunk, o.TempDir)
+			if err != nil {
+				cleanup()
+				return nil, nil, err
+			}
+			runs = append(runs, r)
+			chunk = chunk[:0]
+		}
+		if v == nil {
+			break
+		}
+	}
+	next, err := mergeDiffRuns(runs, s)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return next, cleanup, nil
+}
+
+// sliceNext returns a next function that returns the elements of the slice.
+func sliceNext(s []interface{}) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		if len(s) == 0 {
+			return nil, io.EOF
+		}
+		v := s[0]
+		s = s[1:]
+		return v, nil
+	}
+}
+
+type byDiffID struct {
+	s  []interface{}
+	id func(interface{}) string
+}
+
+func (b byDiffID) Len() int           { return len(b.s) }
+func (b byDiffID) Less(i, j int) bool { return b.id(b.s[i]) < b.id(b.s[j]) }
+func (b byDiffID) Swap(i, j int)      { b.s[i], b.s[j] = b.s[j], b.s[i] }
+
+// diffRun is a sorted run of objects stored in a temporary file as MessagePack.
+// Binary handles do not use the text marshalers of the code types,
+// so unknown values are stored unchanged.
+type diffRun struct {
+	f    *os.File
+	dec  *codec.Decoder
+	n    int // Objects left in the file.
+	head interface{}
+}
+
+var diffRunHandle codec.MsgpackHandle
+
+// writeDiffRun writes the sorted objects to a new temporary file.
+func writeDiffRun(s []interface{}, dir string) (*diffRun, error) {
+	f, err := ioutil.TempFile(dir, "dawa-diff-")
+	if err != nil {
+		return nil, err
+	}
+	r := &diffRun{f: f, n: len(s)}
+	w := bufio.NewWriter(f)
+	enc := codec.NewEncoder(w, &diffRunHandle)
+	for _, v := range s {
+		err = enc.Encode(v)
+		if err != nil {
+			r.close()
+			return nil, err
+		}
+	}
+	err = w.Flush()
+	if err == nil {
+		_, err = f.Seek(0, 0)
+	}
+	if err != nil {
+		r.close()
+		return nil, err
+	}
+	r.dec = codec.NewDecoder(bufio.NewReader(f), &diffRunHandle)
+	return r, nil
+}
+
+// advance reads the next object of the run into head.
+// head is nil when the run is exhausted.
+func (r *diffRun) advance(alloc func() interface{}) error {
+	if r.n == 0 {
+		r.head = nil
+		return nil
+	}
+	v := alloc()
+	err := r.dec.Decode(v)
+	if err != nil {
+		return err
+	}
+	r.n--
+	r.head = v
+	return nil
+}
+
+func (r *diffRun) close() {
+	r.f.Close()
+	os.Remove(r.f.Name())
+}
+
+// diffRunHeap orders runs by the ID of their head.
+type diffRunHeap struct {
+	runs []*diffRun
+	id   func(interface{}) string
+}
+
+func (h diffRunHeap) Len() int            { return len(h.runs) }
+func (h diffRunHeap) Less(i, j int) bool  { return h.id(h.runs[i].head) < h.id(h.runs[j].head) }
+func (h diffRunHeap) Swap(i, j int)       { h.runs[i], h.runs[j] = h.runs[j], h.runs[i] }
+func (h *diffRunHeap) Push(x interface{}) { h.runs = append(h.runs, x.(*diffRun)) }
+func (h *diffRunHeap) Pop() interface{} {
+	r := h.runs[len(h.runs)-1]
+	h.runs = h.runs[:len(h.runs)-1]
+	return r
+}
+
+// mergeDiffRuns returns a next function that merges the sorted runs.
+func mergeDiffRuns(runs []*diffRun, s diffSource) (func() (interface{}, error), error) {
+	h := &diffRunHeap{id: s.id}
+	for _, r := range runs {
+		err := r.advance(s.alloc)
+		if err != nil {
+			return nil, err
+		}
+		if r.head != nil {
+			h.runs = append(h.runs, r)
+		}
+	}
+	heap.Init(h)
+	return func() (interface{}, error) {
+		if h.Len() == 0 {
+			return nil, io.EOF
+		}
+		r := h.runs[0]
+		v := r.head
+		err := r.advance(s.alloc)
+		if err != nil {
+			return nil, err
+		}
+		if r.head == nil {
+			heap.Pop(h)
+		} else {
+			heap.Fix(h, 0)
+		}
+		return v, nil
+	}, nil
+}
@@ -0,0 +1,169 @@
+package dawa
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+var diff_old_input = `[
+{"id":"c","status":1,"etage":"1","dør":"th","adgangsadresse":{"id":"x","husnr":"1","vejstykke":{"navn":"Testvej"}}},
+{"id":"a","status":1,"etage":"st","dør":"","adgangsadresse":{"id":"x","husnr":"1","vejstykke":{"navn":"Testvej"}}},
+{"id":"b","status":1,"etage":"2","dør":"tv","adgangsadresse":{"id":"x","husnr":"1","vejstykke":{"navn":"Testvej"}}}
+]`
+
+var diff_new_input = `[
+{"id":"d","status":3,"etage":"3","dør":"","adgangsadresse":{"id":"x","husnr":"1","vejstykke":{"navn":"Testvej"}}},
+{"id":"b","status":1,"etage":"2","dør":"tv","adgangsadresse":{"id":"x","husnr":"1","vejstykke":{"navn":"Testvej"}}},
+{"id":"c","status":1,"etage":"1","dør":"tv","adgangsadresse":{"id":"x","husnr":"1","vejstykke":{"navn":"Prøvevej"}}}
+]`
+
+func diffAdresserTest(t *testing.T, old, new string, opt *DiffOptions) []AdresseChange {
+	a, err := ImportAdresserJSON(bytes.NewBufferString(old))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ImportAdresserJSON(bytes.NewBufferString(new))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res []AdresseChange
+	err = DiffAdresser(a, b, opt, func(c AdresseChange) error {
+		res = append(res, c)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestDiffAdresser(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dawa-diff-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, opt := range []*DiffOptions{nil, &DiffOptions{MaxInMemory: 1, TempDir: dir}, &DiffOptions{MaxInMemory: 2, TempDir: dir}} {
+		res := diffAdresserTest(t, diff_old_input, diff_new_input, opt)
+		if len(res) != 3 {
+			t.Fatalf("expected 3 changes, got %d: %#v", len(res), res)
+		}
+		if res[0].Type != ChangeRemoved || res[0].ID != "a" || res[0].New != nil || res[0].Old == nil {
+			t.Fatalf("unexpected change: %#v", res[0])
+		}
+		if res[1].Type != ChangeModified || res[1].ID != "c" {
+			t.Fatalf("unexpected change: %#v", res[1])
+		}
+		if len(res[1].Fields) != 2 {
+			t.Fatalf("expected 2 field changes, got %#v", res[1].Fields)
+		}
+		if f := res[1].Fields[0]; f.Field != "Adgangsadresse.Vejstykke.Navn" || f.Old != "Testvej" || f.New != "Prøvevej" {
+			t.Fatalf("unexpected field change: %#v", f)
+		}
+		if f := res[1].Fields[1]; f.Field != "Dør" || f.Old != Dør("th") || f.New != Dør("tv") {
+			t.Fatalf("unexpected field change: %#v", f)
+		}
+		if res[2].Type != ChangeAdded || res[2].ID != "d" || res[2].Old != nil || res[2].New == nil {
+			t.Fatalf("unexpected change: %#v", res[2])
+		}
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 0 {
+		t.Fatalf("temporary files were not removed: %d", len(files))
+	}
+}
+
+func TestDiffAdresserSorted(t *testing.T) {
+	old := `[{"id":"a","status":1},{"id":"b","status":1}]`
+	new := `[{"id":"b","status":3},{"id":"c","status":1}]`
+	res := diffAdresserTest(t, old, new, &DiffOptions{Sorted: true})
+	if len(res) != 3 || res[0].Type != ChangeRemoved || res[1].Type != ChangeModified || res[2].Type != ChangeAdded {
+		t.Fatalf("unexpected changes: %#v", res)
+	}
+
+	a, _ := ImportAdresserJSON(bytes.NewBufferString(new))
+	b, _ := ImportAdresserJSON(bytes.NewBufferString(old))
+	err := DiffAdresser(b, a, &DiffOptions{Sorted: true}, func(c AdresseChange) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ = ImportAdresserJSON(bytes.NewBufferString(`[{"id":"b"},{"id":"a"}]`))
+	b, _ = ImportAdresserJSON(bytes.NewBufferString(old))
+	err = DiffAdresser(a, b, &DiffOptions{Sorted: true}, func(c AdresseChange) error { return nil })
+	if err != ErrNotSorted {
+		t.Fatalf("expected ErrNotSorted, got %v", err)
+	}
+}
+
+func TestDiffAdgangsAdresser(t *testing.T) {
+	old := `[{"id":"a","husnr":"1","adgangspunkt":{"koordinater":[12.5,55.6]}}]`
+	new := `[{"id":"a","husnr":"1","adgangspunkt":{"koordinater":[12.6,55.6]}}]`
+	a, _ := ImportAdgangsAdresserJSON(bytes.NewBufferString(old))
+	b, _ := ImportAdgangsAdresserJSON(bytes.NewBufferString(new))
+	var res []AdgangsAdresseChange
+	err := DiffAdgangsAdresser(a, b, &DiffOptions{MaxInMemory: 1}, func(c AdgangsAdresseChange) error {
+		res = append(res, c)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 || res[0].Type != ChangeModified || len(res[0].Fields) != 1 || res[0].Fields[0].Field != "Adgangspunkt.Koordinater" {
+		t.Fatalf("unexpected changes: %#v", res)
+	}
+}
+
+func TestDiffMaxInMemory(t *testing.T) {
+	// Records that hold values the importers keep for Validate,
+	// or that the text unmarshalers would normalize, must survive sorting on disk.
+	højde := 0.0
+	records := func(zone Zone) []AdgangsAdresse {
+		var res []AdgangsAdresse
+		for _, id := range []string{"c", "a", "b"} {
+			a := AdgangsAdresse{ID: id, Husnr: "1", Status: -1, Zone: zone}
+			a.Historik.Oprettet = MustParseTime("2014-05-05T19:07:48.577")
+			a.Adgangspunkt.Nøjagtighed = "q"
+			a.Adgangspunkt.Kilde = 9
+			a.Adgangspunkt.Koordinater = []float64{}
+			a.Adgangspunkt.Højde = &højde
+			res = append(res, a)
+		}
+		return res
+	}
+	iter := func(s []AdgangsAdresse) *AdgangsAdresseIter {
+		ret := &AdgangsAdresseIter{a: make(chan AdgangsAdresse, len(s)), err: io.EOF}
+		for _, a := range s {
+			ret.a <- a
+		}
+		close(ret.a)
+		return ret
+	}
+	old := records("Ukendt")
+	new := records("Ukendt")
+	new[2].Zone = "byzone"
+
+	for _, opt := range []*DiffOptions{{MaxInMemory: 1000}, {MaxInMemory: 1}} {
+		var res []AdgangsAdresseChange
+		err := DiffAdgangsAdresser(iter(old), iter(new), opt, func(c AdgangsAdresseChange) error {
+			res = append(res, c)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("MaxInMemory %d: %v", opt.MaxInMemory, err)
+		}
+		if len(res) != 1 || res[0].ID != "b" || len(res[0].Fields) != 1 || res[0].Fields[0].Field != "Zone" || res[0].New.Zone != "byzone" {
+			t.Fatalf("MaxInMemory %d: unexpected changes: %#v", opt.MaxInMemory, res)
+		}
+		if f := diffFields(reflect.ValueOf(*res[0].Old), reflect.ValueOf(old[2]), "", nil); len(f) > 0 {
+			t.Fatalf("MaxInMemory %d: old record changed: %#v", opt.MaxInMemory, f)
+		}
+		if f := diffFields(reflect.ValueOf(*res[0].New), reflect.ValueOf(new[2]), "", nil); len(f) > 0 {
+			t.Fatalf("MaxInMemory %d: new record changed: %#v", opt.MaxInMemory, f)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package dawa
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Etage is an etagebetegnelse.
+// Hvis værdi angivet kan den antage følgende værdier: tal fra 1 til 99, st, kl, kl2 op til kl9.
+// An empty Etage means that the adresse has no floor.
+//
+// Floors can be compared in physical order, so "kl2" < "kl" < "st" < "1" < "2".
+type Etage string
+
+// ParseEtage will parse and normalize an etagebetegnelse.
+// Surrounding spaces, a trailing dot and leading zeros are removed and the value is converted to lower case.
+// "stuen" and "kælder" are accepted as "st" and "kl", so " 02. " is returned as "2", and "Stuen" as "st".
+// An error is returned if the result is not a valid etagebetegnelse.
+func ParseEtage(s string) (Etage, error) {
+	v := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(s), "."))
+	switch v {
+	case "stuen":
+		v = "st"
+	case "kælder", "kaelder":
+		v = "kl"
+	}
+	if v != "" && v[0] >= '0' && v[0] <= '9' {
+		v = strings.TrimLeft(v, "0")
+	}
+	e := Etage(v)
+	if _, ok := e.niveau(); !ok {
+		return "", fmt.Errorf("invalid etage '%s'", s)
+	}
+	return e, nil
+}
+
+// niveau returns the physical level of the floor.
+// Stueetagen is 0, kl is -1, kl2 is -2 and so on.
+func (e Etage) niveau() (int, bool) {
+	s := string(e)
+	switch {
+	case s == "st":
+		return 0, true
+	case s == "kl":
+		return -1, true
+	case len(s) == 3 && strings.HasPrefix(s, "kl") && s[2] >= '2' && s[2] <= '9':
+		return -int(s[2] - '0'), true
+	case len(s) == 0 || len(s) > 2 || s[0] == '0':
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
+
+// Valid returns true if the etagebetegnelse is valid.
+// An empty value is not valid. Use an empty value to indicate no floor.
+func (e Etage) Valid() bool {
+	_, ok := e.niveau()
+	return ok
+}
+
+// Niveau returns the physical level of the floor.
+// Stueetagen is 0, floors above are positive and basement floors are negative,
+// so "kl" is -1 and "kl2" is -2.
+// 0 is also returned if the etagebetegnelse is not valid.
+func (e Etage) Niveau() int {
+	n, _ := e.niveau()
+	return n
+}
+
+// String returns the etagebetegnelse.
+func (e Etage) String() string {
+	return string(e)
+}
+
+// Formateret returns the etagebetegnelse as used in a formatted address, for example "st." or "2.".
+// Invalid values are returned unchanged.
+func (e Etage) Formateret() string {
+	if !e.Valid() {
+		return string(e)
+	}
+	return string(e) + "."
+}
+
+// Less returns true if e is physically below o.
+func (e Etage) Less(o Etage) bool {
+	return CompareEtage(e, o) < 0
+}
+
+// CompareEtage compares two floors in physical order.
+// The result will be 0 if a==b, -1 if a < b, and +1 if a > b.
+//
+// An empty value, meaning no floor, is placed first.
+// Invalid values are placed after valid ones, ordered as strings.
+func CompareEtage(a, b Etage) int {
+	if a == "" || b == "" {
+		return strings.Compare(string(a), string(b))
+	}
+	an, aok := a.niveau()
+	bn, bok := b.niveau()
+	switch {
+	case aok && !bok:
+		return -1
+	case !aok && bok:
+		return 1
+	case !aok && !bok:
+		return strings.Compare(string(a), string(b))
+	case an < bn:
+		return -1
+	case an > bn:
+		return 1
+	}
+	return 0
+}
+
+// SortEtage sorts floors in physical order.
+func SortEtage(e []Etage) {
+	sort.Sort(etageSlice(e))
+}
+
+type etageSlice []Etage
+
+func (e etageSlice) Len() int           { return len(e) }
+func (e etageSlice) Less(i, j int) bool { return e[i].Less(e[j]) }
+func (e etageSlice) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+
+// Dør is a dørbetegnelse.
+// Tal fra 1 til 9999, små og store bogstaver samt tegnene / og -. Højst 4 tegn.
+// The most common values are "tv", "mf" and "th".
+// An empty Dør means that the adresse has no door.
+type Dør string
+
+// Common dørbetegnelser.
+const (
+	DørTv Dør = "tv" // Til venstre.
+	DørMf Dør = "mf" // Midtfor.
+	DørTh Dør = "th" // Til højre.
+)
+
+// ParseDør will parse and normalize a dørbetegnelse.
+// Surrounding spaces, a trailing dot and leading zeros of numbers are removed,
+// and "tv", "mf" and "th" are converted to lower case, so "TH." is returned as "th".
+// An error is returned if the result is not a valid dørbetegnelse.
+func ParseDør(s string) (Dør, error) {
+	v := strings.TrimSuffix(strings.TrimSpace(s), ".")
+	switch l := strings.ToLower(v); Dør(l) {
+	case DørTv, DørMf, DørTh:
+		v = l
+	}
+	if validDigits(v) {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			v = strconv.Itoa(n)
+		}
+	}
+	d := Dør(v)
+	if !d.Valid() {
+		return "", fmt.Errorf("invalid dør '%s'", s)
+	}
+	return d, nil
+}
+
+// Valid returns true if the dørbetegnelse is valid.
+// An empty value is not valid. Use an empty value to indicate no door.
+func (d Dør) Valid() bool {
+	s := string(d)
+	if s == "" || len([]rune(s)) > 4 {
+		return false
+	}
+	if validDigits(s) {
+		return s[0] != '0'
+	}
+	// A sign is not allowed, so "-" may only be used between other characters.
+	if s[0] == '-' || s[0] == '/' {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '/', r == '-':
+		case strings.ContainsRune("æøåÆØÅ", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the dørbetegnelse.
+func (d Dør) String() string {
+	return string(d)
+}
+
+// Formateret returns the dørbetegnelse as used in a formatted address.
+// "tv", "mf" and "th" are returned as "tv.", "mf." and "th.". Other values are returned unchanged.
+func (d Dør) Formateret() string {
+	switch d {
+	case DørTv, DørMf, DørTh:
+		return string(d) + "."
+	}
+	return string(d)
+}
+
+// Less returns true if d is before o.
+func (d Dør) Less(o Dør) bool {
+	return CompareDør(d, o) < 0
+}
+
+// CompareDør compares two dørbetegnelser.
+// The result will be 0 if a==b, -1 if a < b, and +1 if a > b.
+//
+// An empty value, meaning no door, is placed first, then "tv", "mf" and "th",
+// then numbers in numerical order and then other values ordered as strings.
+func CompareDør(a, b Dør) int {
+	ar, br := a.rank(), b.rank()
+	if ar != br {
+		if ar < br {
+			return -1
+		}
+		return 1
+	}
+	if ar == 4 {
+		an, _ := strconv.Atoi(string(a))
+		bn, _ := strconv.Atoi(string(b))
+		if an != bn {
+			if an < bn {
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(string(a), string(b))
+}
+
+// rank returns the group of the dørbetegnelse used for ordering.
+func (d Dør) rank() int {
+	switch d {
+	case "":
+		return 0
+	case DørTv:
+		return 1
+	case DørMf:
+		return 2
+	case DørTh:
+		return 3
+	}
+	if _, err := strconv.Atoi(string(d)); err == nil {
+		return 4
+	}
+	return 5
+}
+
+// SortDør sorts dørbetegnelser. See CompareDør for the order.
+func SortDør(d []Dør) {
+	sort.Sort(dørSlice(d))
+}
+
+type dørSlice []Dør
+
+func (d dørSlice) Len() int           { return len(d) }
+func (d dørSlice) Less(i, j int) bool { return d[i].Less(d[j]) }
+func (d dørSlice) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+
+// FormatEtageDør returns the formatted etage and dør of an adresse, for example "st. th.", "2. tv." or "3.".
+// An empty string is returned if both are empty.
+func FormatEtageDør(e Etage, d Dør) string {
+	var parts []string
+	if e != "" {
+		parts = append(parts, e.Formateret())
+	}
+	if d != "" {
+		parts = append(parts, d.Formateret())
+	}
+	return strings.Join(parts, " ")
+}
+
+// etageValue returns the etagebetegnelse normalized if it is valid.
+// Other values are returned unchanged, so Validate can report them.
+func etageValue(s string) Etage {
+	if e, err := ParseEtage(s); err == nil {
+		return e
+	}
+	return Etage(s)
+}
+
+// dørValue returns the dørbetegnelse normalized if it is valid.
+// Other values are returned unchanged, so Validate can report them.
+func dørValue(s string) Dør {
+	if d, err := ParseDør(s); err == nil {
+		return d
+	}
+	return Dør(s)
+}
+
+// etageValues returns the values with valid etagebetegnelser normalized.
+// Other values are returned unchanged.
+func etageValues(s []string) []string {
+	res := make([]string, len(s))
+	for i, v := range s {
+		if e, err := ParseEtage(v); err == nil {
+			v = string(e)
+		}
+		res[i] = v
+	}
+	return res
+}
+
+// dørValues returns the values with valid dørbetegnelser normalized.
+// Other values are returned unchanged.
+func dørValues(s []string) []string {
+	res := make([]string, len(s))
+	for i, v := range s {
+		if d, err := ParseDør(v); err == nil {
+			v = string(d)
+		}
+		res[i] = v
+	}
+	return res
+}
@@ -0,0 +1,86 @@
+package dawa
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEtage(t *testing.T) {
+	valid := map[string]Etage{"st": "st", "ST.": "st", "Stuen": "st", " 02. ": "2", "99": "99", "kl": "kl", "kælder": "kl", "KL9": "kl9"}
+	for in, want := range valid {
+		got, err := ParseEtage(in)
+		if err != nil || got != want {
+			t.Errorf("ParseEtage(%q): got %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "0", "100", "kl1", "kl10", "1a", "-1"} {
+		if _, err := ParseEtage(in); err == nil {
+			t.Errorf("ParseEtage(%q): expected error", in)
+		}
+	}
+	if Etage("kl3").Niveau() != -3 || Etage("st").Niveau() != 0 || Etage("12").Niveau() != 12 {
+		t.Fatal("unexpected niveau")
+	}
+}
+
+func TestSortEtage(t *testing.T) {
+	e := []Etage{"2", "x", "st", "kl", "10", "", "kl2", "1"}
+	SortEtage(e)
+	want := []Etage{"", "kl2", "kl", "st", "1", "2", "10", "x"}
+	if !reflect.DeepEqual(e, want) {
+		t.Fatalf("got %v, want %v", e, want)
+	}
+}
+
+func TestParseDør(t *testing.T) {
+	valid := map[string]Dør{"th": "th", "TH.": "th", " tv ": "tv", "0012": "12", "9999": "9999", "A": "A", "12/3": "12/3", "b-2": "b-2", "æ": "æ"}
+	for in, want := range valid {
+		got, err := ParseDør(in)
+		if err != nil || got != want {
+			t.Errorf("ParseDør(%q): got %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "0", "10000", "abcde", "1 2", "a_b", "+5", "-5"} {
+		if _, err := ParseDør(in); err == nil {
+			t.Errorf("ParseDør(%q): expected error", in)
+		}
+		if Dør(in).Valid() {
+			t.Errorf("Dør(%q).Valid(): expected false", in)
+		}
+	}
+}
+
+func TestSortDør(t *testing.T) {
+	d := []Dør{"B", "10", "th", "2", "", "tv", "mf", "A"}
+	SortDør(d)
+	want := []Dør{"", "tv", "mf", "th", "2", "10", "A", "B"}
+	if !reflect.DeepEqual(d, want) {
+		t.Fatalf("got %v, want %v", d, want)
+	}
+}
+
+func TestFormatEtageDør(t *testing.T) {
+	tests := []struct {
+		e    Etage
+		d    Dør
+		want string
+	}{
+		{"st", "th", "st. th."},
+		{"2", "tv", "2. tv."},
+		{"3", "", "3."},
+		{"", "12", "12"},
+		{"", "", ""},
+	}
+	for _, test := range tests {
+		if got := FormatEtageDør(test.e, test.d); got != test.want {
+			t.Errorf("FormatEtageDør(%q, %q): got %q, want %q", test.e, test.d, got, test.want)
+		}
+	}
+}
+
+func TestEtageDørQuery(t *testing.T) {
+	u := NewAdresseQuery().Etage("Stuen", "02").Dør("TH.", "").URL()
+	if u != DefaultHost+"/adresser?etage=st|2&d%C3%B8r=th|" {
+		t.Fatalf("unexpected url %s", u)
+	}
+}
@@ -0,0 +1,236 @@
+package dawa
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/ugorji/go/codec"
+)
+
+// MultiPolygon contains a polygonal geometry.
+//
+// It is a list of polygons, each consisting of a number of rings, which is a list of [x,y] coordinates.
+// The first ring of each polygon is the outer boundary, any following rings are holes.
+//
+// A GeoJSON "Polygon" is decoded as a MultiPolygon with a single polygon.
+type MultiPolygon [][][][]float64
+
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// UnmarshalJSON will decode a GeoJSON "Polygon" or "MultiPolygon" geometry object.
+func (m *MultiPolygon) UnmarshalJSON(b []byte) error {
+	var g geoJSONGeometry
+	err := json.Unmarshal(b, &g)
+	if err != nil {
+		return err
+	}
+	switch g.Type {
+	case "":
+		*m = nil
+		return nil
+	case "Polygon":
+		var p [][][]float64
+		err = json.Unmarshal(g.Coordinates, &p)
+		if err != nil {
+			return err
+		}
+		*m = MultiPolygon{p}
+	case "MultiPolygon":
+		var p [][][][]float64
+		err = json.Unmarshal(g.Coordinates, &p)
+		if err != nil {
+			return err
+		}
+		*m = MultiPolygon(p)
+	default:
+		return fmt.Errorf("unsupported geometry type '%s'", g.Type)
+	}
+	return nil
+}
+
+// MarshalJSON will encode the geometry as a GeoJSON "MultiPolygon".
+func (m MultiPolygon) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(struct {
+		Type        string          `json:"type"`
+		Coordinates [][][][]float64 `json:"coordinates"`
+	}{Type: "MultiPolygon", Coordinates: m})
+}
+
+// Bounds returns the bounding box of the geometry as minimum and maximum coordinates.
+// If the geometry is empty, all values will be 0.
+func (m MultiPolygon) Bounds() (minX, minY, maxX, maxY float64) {
+	first := true
+	for _, poly := range m {
+		for _, ring := range poly {
+			for _, p := range ring {
+				if len(p) < 2 {
+					continue
+				}
+				if first {
+					minX, maxX, minY, maxY = p[0], p[0], p[1], p[1]
+					first = false
+					continue
+				}
+				if p[0] < minX {
+					minX = p[0]
+				}
+				if p[0] > maxX {
+					maxX = p[0]
+				}
+				if p[1] < minY {
+					minY = p[1]
+				}
+				if p[1] > maxY {
+					maxY = p[1]
+				}
+			}
+		}
+	}
+	return
+}
+
+// Contains returns true if the point (x, y) is inside the geometry.
+// A point inside a hole of a polygon is not contained.
+// Points exactly on the boundary may be reported as either inside or outside.
+func (m MultiPolygon) Contains(x, y float64) bool {
+	for _, poly := range m {
+		// Using the even-odd rule across all rings handles holes.
+		in := false
+		for _, ring := range poly {
+			if ringContains(ring, x, y) {
+				in = !in
+			}
+		}
+		if in {
+			return true
+		}
+	}
+	return false
+}
+
+// ringContains returns true if the point (x, y) is inside the ring.
+func ringContains(ring [][]float64, x, y float64) bool {
+	in := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if len(a) < 2 || len(b) < 2 {
+			continue
+		}
+		if (a[1] > y) != (b[1] > y) && x < (b[0]-a[0])*(y-a[1])/(b[1]-a[1])+a[0] {
+			in = !in
+		}
+	}
+	return in
+}
+
+// Transform returns a copy of the geometry with fn applied to all coordinates.
+func (m MultiPolygon) Transform(fn func(x, y float64) (float64, float64)) MultiPolygon {
+	res := make(MultiPolygon, len(m))
+	for i, poly := range m {
+		res[i] = make([][][]float64, len(poly))
+		for j, ring := range poly {
+			res[i][j] = make([][]float64, len(ring))
+			for k, p := range ring {
+				if len(p) < 2 {
+					continue
+				}
+				x, y := fn(p[0], p[1])
+				res[i][j][k] = []float64{x, y}
+			}
+		}
+	}
+	return res
+}
+
+// A GeoJSON feature with a polygon geometry and flat properties,
+// as returned by DAWA when requesting "format=geojson".
+type geoJSONFeature struct {
+	Geometry   MultiPolygon    `json:"geometry"`
+	Properties geoJSONProperty `json:"properties"`
+}
+
+// geoJSONProperty contains flat GeoJSON properties.
+// DAWA may deliver codes as either strings or numbers, so the accessors
+// will convert between the two.
+type geoJSONProperty map[string]interface{}
+
+func (p geoJSONProperty) String(key string) string {
+	switch v := p[key].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+func (p geoJSONProperty) Int(key string) int {
+	switch v := p[key].(type) {
+	case float64:
+		return int(v)
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	}
+	return 0
+}
+
+func (p geoJSONProperty) Float(key string) float64 {
+	switch v := p[key].(type) {
+	case float64:
+		return v
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	return 0
+}
+
+func (p geoJSONProperty) Bool(key string) bool {
+	switch v := p[key].(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
+
+// decodeGeoJSONFeatures will decode a GeoJSON FeatureCollection from the reader
+// and call fn with each feature.
+// The features are streamed, so only a few features are in memory at a time.
+func decodeGeoJSONFeatures(in io.Reader, fn func(f geoJSONFeature)) error {
+	var h codec.JsonHandle
+	// Decode property numbers as float64, like encoding/json.
+	h.PreferFloat = true
+	// use a buffered reader for efficiency
+	if _, ok := in.(io.ByteScanner); !ok {
+		in = bufio.NewReader(in)
+	}
+	fc := struct {
+		Features chan geoJSONFeature `json:"features"`
+	}{Features: make(chan geoJSONFeature, 100)}
+
+	errc := make(chan error, 1)
+	go func() {
+		defer close(fc.Features)
+		var dec *codec.Decoder = codec.NewDecoder(in, &h)
+		errc <- dec.Decode(&fc)
+	}()
+	for f := range fc.Features {
+		fn(f)
+	}
+	return <-errc
+}
@@ -0,0 +1,103 @@
+package dawa
+
+import (
+	"context"
+	"strconv"
+)
+
+// HændelseQuery is a new query for 'hændelser' of a single entity type in the DAWA replication API.
+// Use NewHændelseQuery() to get an initialized object.
+// Example:
+//			// Get all changes to adresser from sekvensnummer 1000 to 2000.
+//			iter, err := dawa.NewHændelseQuery(dawa.EntityAdresse).SekvensnummerFra(1000).SekvensnummerTil(2000).Iter()
+type HændelseQuery struct {
+	query
+	entity Entity
+}
+
+// NewHændelseQuery returns a new query for 'hændelser' of the given entity type.
+//
+// See documentation at http://dawa.aws.dk/replikeringdok
+func NewHændelseQuery(entity Entity) *HændelseQuery {
+	return &HændelseQuery{entity: entity, query: query{host: DefaultHost, path: "/replikering/" + string(entity) + "/haendelser"}}
+}
+
+// Iter will return an iterator that allows you to read the hændelser
+// one by one, in sekvensnummer order.
+func (q HændelseQuery) Iter() (*HændelseIter, error) {
+	return q.IterContext(context.Background())
+}
+
+// IterContext will return an iterator like Iter(),
+// but the request will be cancelled if the context is cancelled.
+func (q HændelseQuery) IterContext(ctx context.Context) (*HændelseIter, error) {
+	resp, err := q.NoFormat().RequestContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := ImportHændelserJSON(q.entity, resp)
+	if err != nil {
+		resp.Close()
+		return nil, err
+	}
+	iter.AddCloser(resp)
+	return iter, nil
+}
+
+// SekvensnummerFra will add a parameter for 'sekvensnummerfra' to the HændelseQuery.
+//
+// Returner hændelser med sekvensnummer større eller lig det angivne.
+//
+// See documentation at http://dawa.aws.dk/replikeringdok
+func (q *HændelseQuery) SekvensnummerFra(i int64) *HændelseQuery {
+	q.add(&textQuery{Name: "sekvensnummerfra", Values: []string{strconv.FormatInt(i, 10)}, Multi: false, Null: false})
+	return q
+}
+
+// SekvensnummerTil will add a parameter for 'sekvensnummertil' to the HændelseQuery.
+//
+// Returner hændelser med sekvensnummer mindre eller lig det angivne.
+//
+// See documentation at http://dawa.aws.dk/replikeringdok
+func (q *HændelseQuery) SekvensnummerTil(i int64) *HændelseQuery {
+	q.add(&textQuery{Name: "sekvensnummertil", Values: []string{strconv.FormatInt(i, 10)}, Multi: false, Null: false})
+	return q
+}
+
+// TidspunktFra will add a parameter for 'tidspunktfra' to the HændelseQuery.
+//
+// Returner hændelser registreret på eller efter det angivne tidspunkt, f.eks. "2014-05-05T19:07:48.577Z".
+//
+// See documentation at http://dawa.aws.dk/replikeringdok
+func (q *HændelseQuery) TidspunktFra(s string) *HændelseQuery {
+	q.add(&textQuery{Name: "tidspunktfra", Values: []string{s}, Multi: false, Null: false})
+	return q
+}
+
+// TidspunktTil will add a parameter for 'tidspunkttil' to the HændelseQuery.
+//
+// Returner hændelser registreret på eller før det angivne tidspunkt, f.eks. "2014-05-05T19:07:48.577Z".
+//
+// See documentation at http://dawa.aws.dk/replikeringdok
+func (q *HændelseQuery) TidspunktTil(s string) *HændelseQuery {
+	q.add(&textQuery{Name: "tidspunkttil", Values: []string{s}, Multi: false, Null: false})
+	return q
+}
+
+// ID will add a parameter for 'id' to the HændelseQuery.
+//
+// Returner kun hændelser for objektet med det angivne id.
+// Understøttes for adresser og adgangsadresser.
+//
+// See documentation at http://dawa.aws.dk/replikeringdok
+func (q *HændelseQuery) ID(s string) *HændelseQuery {
+	q.add(&textQuery{Name: "id", Values: []string{s}, Multi: false, Null: false})
+	return q
+}
+
+// NoFormat will disable extra whitespace. Always enabled when querying
+func (q *HændelseQuery) NoFormat() *HændelseQuery {
+	q.add(&textQuery{Name: "noformat", Multi: false, Null: true})
+	return q
+}
@@ -0,0 +1,330 @@
+package dawa
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/ugorji/go/codec"
+)
+
+// Entity is the name of an entity type in the DAWA replication API.
+type Entity string
+
+const (
+	EntityAdresse        Entity = "adresser"        // Adresser. Payload is ReplikeringAdresse.
+	EntityAdgangsAdresse Entity = "adgangsadresser" // Adgangsadresser. Payload is ReplikeringAdgangsAdresse.
+	EntityVejstykke      Entity = "vejstykker"      // Vejstykker. Payload is ReplikeringVejstykke.
+	EntityPostnummer     Entity = "postnumre"       // Postnumre. Payload is ReplikeringPostnummer.
+)
+
+// Entities contains all entity types supported by the replication API,
+// in the order they should be loaded to satisfy references.
+var Entities = []Entity{EntityPostnummer, EntityVejstykke, EntityAdgangsAdresse, EntityAdresse}
+
+// Operation is the type of change of a Hændelse.
+type Operation string
+
+const (
+	OperationInsert Operation = "insert" // The object was created.
+	OperationUpdate Operation = "update" // The object was changed.
+	OperationDelete Operation = "delete" // The object was deleted. The payload contains the object before deletion.
+)
+
+// ReplikeringAdresse is an 'adresse' in the flat format used by the replication API.
+type ReplikeringAdresse struct {
+	ID                  string  `json:"id"`                  // Adressens unikke id.
+	Status              Status  `json:"status"`              // Adressens status. 1 indikerer en gældende adresse, 3 indikerer en foreløbig adresse.
+	Oprettet            AwsTime `json:"oprettet"`            // Dato og tid for adressens oprettelse.
+	Ændret              AwsTime `json:"ændret"`              // Dato og tid hvor der sidst er ændret i adressen.
+	Ikrafttrædelsesdato AwsTime `json:"ikrafttrædelsesdato"` // Adressens ikrafttrædelsesdato.
+	AdgangsadresseID    string  `json:"adgangsadresseid"`    // Id på den til adressen tilknyttede adgangsadresse.
+	Etage               Etage   `json:"etage"`               // Etagebetegnelse.
+	Dør                 Dør     `json:"dør"`                 // Dørbetegnelse.
+	Kilde               Kilde   `json:"kilde"`               // Kode der angiver kilden til adressen. Samme koder som for adressepunktet.
+	EsdhReference       string  `json:"esdhreference"`       // Nøgle i ESDH system.
+	Journalnummer       string  `json:"journalnummer"`       // Journalnummer.
+}
+
+// ReplikeringAdgangsAdresse is an 'adgangsadresse' in the flat format used by the replication API.
+type ReplikeringAdgangsAdresse struct {
+	ID                       string          `json:"id"`                       // Adgangsadressens unikke id.
+	Status                   Status          `json:"status"`                   // Adgangsadressens status. 1 indikerer en gældende adresse, 3 indikerer en foreløbig adresse.
+	Oprettet                 AwsTime         `json:"oprettet"`                 // Dato og tid for adgangsadressens oprettelse.
+	Ændret                   AwsTime         `json:"ændret"`                   // Dato og tid hvor der sidst er ændret i adgangsadressen.
+	Ikrafttrædelsesdato      AwsTime         `json:"ikrafttrædelsesdato"`      // Adgangsadressens ikrafttrædelsesdato.
+	Kommunekode              int             `json:"kommunekode"`              // Kommunekoden.
+	Vejkode                  int             `json:"vejkode"`                  // Vejkoden.
+	Husnr                    Husnr           `json:"husnr"`                    // Husnummer.
+	SupplerendeBynavn        string          `json:"supplerendebynavn"`        // Supplerende bynavn.
+	Postnr                   int             `json:"postnr"`                   // Postnummer.
+	Ejerlavkode              int             `json:"ejerlavkode"`              // Koden på det matrikulære ejerlav.
+	Matrikelnr               string          `json:"matrikelnr"`               // Matrikelnummer.
+	EsrEjendomsNr            int             `json:"esrejendomsnr"`            // ESR Ejendomsnummer.
+	Etrs89KoordinatØst       float64         `json:"etrs89koordinat_øst"`      // Adgangspunktets øst-koordinat i ETRS89/UTM32.
+	Etrs89KoordinatNord      float64         `json:"etrs89koordinat_nord"`     // Adgangspunktets nord-koordinat i ETRS89/UTM32.
+	Nøjagtighed              Nøjagtighed     `json:"nøjagtighed"`              // Kode der angiver nøjagtigheden for adressepunktet.
+	Kilde                    Kilde           `json:"kilde"`                    // Kode der angiver kilden til adressepunktet.
+	Husnummerkilde           int             `json:"husnummerkilde"`           // Kode der angiver kilden til husnummeret.
+	Tekniskstandard          Tekniskstandard `json:"tekniskstandard"`          // Kode der angiver den specifikation adressepunktet skal opfylde.
+	Tekstretning             Tekstretning    `json:"tekstretning"`             // Retningsvinkel for adressen i gon.
+	AdressepunktÆndringsdato AwsTime         `json:"adressepunktændringsdato"` // Dato for sidste ændring i adressepunktet.
+	EsdhReference            string          `json:"esdhreference"`            // Nøgle i ESDH system.
+	Journalnummer            string          `json:"journalnummer"`            // Journalnummer.
+	Højde                    *float64        `json:"højde"`                    // Terrænhøjden ved adgangspunktet. nil hvis højden ikke er kendt.
+}
+
+// ReplikeringVejstykke is a 'vejstykke' in the flat format used by the replication API.
+type ReplikeringVejstykke struct {
+	Kommunekode      int     `json:"kommunekode"`      // Kommunekoden.
+	Kode             int     `json:"kode"`             // Vejkoden. Unik indenfor kommunen.
+	Oprettet         AwsTime `json:"oprettet"`         // Dato og tid for vejstykkets oprettelse.
+	Ændret           AwsTime `json:"ændret"`           // Dato og tid hvor der sidst er ændret i vejstykket.
+	Navn             string  `json:"navn"`             // Vejens navn.
+	Adresseringsnavn string  `json:"adresseringsnavn"` // En evt. forkortet udgave af vejnavnet på højst 20 tegn.
+}
+
+// ReplikeringPostnummer is a 'postnummer' in the flat format used by the replication API.
+type ReplikeringPostnummer struct {
+	Nr           int    `json:"nr"`           // Postnummeret.
+	Navn         string `json:"navn"`         // Postnummerets navn.
+	Stormodtager bool   `json:"stormodtager"` // Angiver om postnummeret er et stormodtagerpostnummer.
+}
+
+// Adresse returns the replicated data as an Adresse.
+// Only fields present in the replication format are filled.
+func (r ReplikeringAdresse) Adresse() Adresse {
+	a := Adresse{}
+	a.ID = r.ID
+	a.Status = r.Status
+	a.Historik.Oprettet = r.Oprettet
+	a.Historik.Ændret = r.Ændret
+	a.Adgangsadresse.ID = r.AdgangsadresseID
+	a.Etage = r.Etage
+	a.Dør = r.Dør
+	return a
+}
+
+// AdgangsAdresse returns the replicated data as an AdgangsAdresse.
+// Only fields present in the replication format are filled.
+// Note that the coordinates are ETRS89/UTM32 [øst, nord].
+func (r ReplikeringAdgangsAdresse) AdgangsAdresse() AdgangsAdresse {
+	a := AdgangsAdresse{}
+	a.ID = r.ID
+	a.Status = r.Status
+	a.Historik.Oprettet = r.Oprettet
+	a.Historik.Ændret = r.Ændret
+	a.Kommune.Kode = fmt.Sprintf("%04d", r.Kommunekode)
+	a.Vejstykke.Kode = fmt.Sprintf("%04d", r.Vejkode)
+	a.Husnr = r.Husnr
+	a.SupplerendeBynavn = r.SupplerendeBynavn
+	a.Postnummer.Nr = fmt.Sprintf("%04d", r.Postnr)
+	a.Ejerlav.Kode = r.Ejerlavkode
+	a.Matrikelnr = r.Matrikelnr
+	if r.EsrEjendomsNr != 0 {
+		a.EsrEjendomsNr = fmt.Sprintf("%d", r.EsrEjendomsNr)
+	}
+	a.Adgangspunkt.Koordinater = []float64{r.Etrs89KoordinatØst, r.Etrs89KoordinatNord}
+	a.Adgangspunkt.Nøjagtighed = r.Nøjagtighed
+	a.Adgangspunkt.Kilde = r.Kilde
+	a.Adgangspunkt.Tekniskstandard = r.Tekniskstandard
+	a.Adgangspunkt.Tekstretning = r.Tekstretning
+	a.Adgangspunkt.Ændret = r.AdressepunktÆndringsdato
+	a.Adgangspunkt.Højde = r.Højde
+	return a
+}
+
+// Vejstykke returns the replicated data as a Vejstykke.
+// Only fields present in the replication format are filled.
+func (r ReplikeringVejstykke) Vejstykke() Vejstykke {
+	v := Vejstykke{}
+	v.Kommune.Kode = fmt.Sprintf("%04d", r.Kommunekode)
+	v.Kode = fmt.Sprintf("%04d", r.Kode)
+	v.Historik.Oprettet = r.Oprettet
+	v.Historik.Ændret = r.Ændret
+	v.Navn = r.Navn
+	v.Adresseringsnavn = r.Adresseringsnavn
+	return v
+}
+
+// Postnummer returns the replicated data as a Postnummer.
+// Only fields present in the replication format are filled.
+func (r ReplikeringPostnummer) Postnummer() Postnummer {
+	return Postnummer{Nr: fmt.Sprintf("%04d", r.Nr), Navn: r.Navn}
+}
+
+// En hændelse beskriver en ændring af et objekt i DAWA.
+// Hændelser er nummereret med et fortløbende sekvensnummer, som er fælles for alle objekttyper.
+//
+// Exactly one of the payload fields is set, matching Entity.
+type Hændelse struct {
+	Entity        Entity    // The entity type of the changed object.
+	Operation     Operation // The type of change.
+	Tidspunkt     AwsTime   // Tidspunktet hvor hændelsen blev registreret i DAWA.
+	Sekvensnummer int64     // Hændelsens unikke sekvensnummer.
+
+	Adresse        *ReplikeringAdresse        // Set if Entity is EntityAdresse.
+	AdgangsAdresse *ReplikeringAdgangsAdresse // Set if Entity is EntityAdgangsAdresse.
+	Vejstykke      *ReplikeringVejstykke      // Set if Entity is EntityVejstykke.
+	Postnummer     *ReplikeringPostnummer     // Set if Entity is EntityPostnummer.
+}
+
+// Key returns a string that uniquely identifies the changed object within its entity type.
+func (h Hændelse) Key() string {
+	switch {
+	case h.Adresse != nil:
+		return h.Adresse.ID
+	case h.AdgangsAdresse != nil:
+		return h.AdgangsAdresse.ID
+	case h.Vejstykke != nil:
+		return fmt.Sprintf("%04d-%04d", h.Vejstykke.Kommunekode, h.Vejstykke.Kode)
+	case h.Postnummer != nil:
+		return fmt.Sprintf("%04d", h.Postnummer.Nr)
+	}
+	return ""
+}
+
+// Common fields of all hændelser as sent by DAWA.
+type hændelseInfo struct {
+	Operation     Operation `json:"operation"`
+	Tidspunkt     AwsTime   `json:"tidspunkt"`
+	Sekvensnummer int64     `json:"sekvensnummer"`
+}
+
+func (h hændelseInfo) hændelse(e Entity) Hændelse {
+	return Hændelse{Entity: e, Operation: h.Operation, Tidspunkt: h.Tidspunkt, Sekvensnummer: h.Sekvensnummer}
+}
+
+type adresseHændelse struct {
+	hændelseInfo
+	Data ReplikeringAdresse `json:"data"`
+}
+
+type adgangsAdresseHændelse struct {
+	hændelseInfo
+	Data ReplikeringAdgangsAdresse `json:"data"`
+}
+
+type vejstykkeHændelse struct {
+	hændelseInfo
+	Data ReplikeringVejstykke `json:"data"`
+}
+
+type postnummerHændelse struct {
+	hændelseInfo
+	Data ReplikeringPostnummer `json:"data"`
+}
+
+// HændelseIter is an Iterator that enable you to get individual entries.
+type HændelseIter struct {
+	a   chan Hændelse
+	err error
+	validating
+	closer
+}
+
+// Next will return the next hændelse.
+// It will return an error if that has been encountered.
+// When there are not more entries nil, io.EOF will be returned.
+func (a *HændelseIter) Next() (*Hændelse, error) {
+	for {
+		v, ok := <-a.a
+		if !ok {
+			return nil, a.err
+		}
+		if !a.skip(&v) {
+			return &v, nil
+		}
+	}
+}
+
+// ValidateTo will make the iterator validate each hændelse with its Validate method.
+// Invalid hændelser are sent to the sink and skipped, instead of aborting the import.
+// It must be called before the first call to Next.
+func (a *HændelseIter) ValidateTo(sink ErrorSink) *HændelseIter {
+	a.sink = sink
+	return a
+}
+
+// ImportHændelserJSON will import "hændelser" of the given entity type from a JSON input, supplied to the reader.
+// An iterator will be returned that return all items.
+func ImportHændelserJSON(entity Entity, in io.Reader) (*HændelseIter, error) {
+	return importHændelserJSON(entity, in, nil)
+}
+
+// hændelseTypes contains the types used for decoding each entity type.
+// wrapped is the type of a hændelse as sent by DAWA, with the object in Data.
+// set stores the decoded object in the matching field of the Hændelse.
+var hændelseTypes = map[Entity]struct {
+	wrapped reflect.Type
+	set     func(h *Hændelse, v interface{})
+}{
+	EntityAdresse:        {reflect.TypeOf(adresseHændelse{}), func(h *Hændelse, v interface{}) { d := v.(ReplikeringAdresse); h.Adresse = &d }},
+	EntityAdgangsAdresse: {reflect.TypeOf(adgangsAdresseHændelse{}), func(h *Hændelse, v interface{}) { d := v.(ReplikeringAdgangsAdresse); h.AdgangsAdresse = &d }},
+	EntityVejstykke:      {reflect.TypeOf(vejstykkeHændelse{}), func(h *Hændelse, v interface{}) { d := v.(ReplikeringVejstykke); h.Vejstykke = &d }},
+	EntityPostnummer:     {reflect.TypeOf(postnummerHændelse{}), func(h *Hændelse, v interface{}) { d := v.(ReplikeringPostnummer); h.Postnummer = &d }},
+}
+
+// importHændelserJSON will import a JSON array of the given entity type.
+// If info is nil, the array contains hændelser as sent by DAWA.
+// Otherwise the array contains the objects, and info is used for all hændelser.
+func importHændelserJSON(entity Entity, in io.Reader, info *hændelseInfo) (*HændelseIter, error) {
+	t, ok := hændelseTypes[entity]
+	if !ok {
+		return nil, fmt.Errorf("unknown entity type '%s'", entity)
+	}
+	eType := t.wrapped
+	if info != nil {
+		f, _ := eType.FieldByName("Data")
+		eType = f.Type
+	}
+	var h codec.JsonHandle
+	h.DecodeOptions.ErrorIfNoField = JSONStrictFieldCheck
+	// use a buffered reader for efficiency
+	if _, ok := in.(io.ByteScanner); !ok {
+		in = bufio.NewReader(in)
+	}
+	ret := &HændelseIter{a: make(chan Hændelse, 100)}
+	var dec *codec.Decoder = codec.NewDecoder(in, &h)
+
+	// We decode into a channel with the expected type,
+	// and convert the elements to hændelser.
+	ch := makeChannel(eType, reflect.BothDir, 100)
+	errc := make(chan error, 1)
+	go func() {
+		channel := ch.Interface()
+		errc <- dec.Decode(&channel)
+		ch.Close()
+	}()
+	go func() {
+		for {
+			v, ok := ch.Recv()
+			if !ok {
+				break
+			}
+			var e Hændelse
+			if info != nil {
+				e = info.hændelse(entity)
+			} else {
+				e = v.Interface().(interface {
+					hændelse(Entity) Hændelse
+				}).hændelse(entity)
+				v = v.FieldByName("Data")
+			}
+			t.set(&e, v.Interface())
+			ret.a <- e
+		}
+		ret.finish(<-errc)
+	}()
+	return ret, nil
+}
+
+// finish sets the final error of the iterator and closes the channel.
+func (a *HændelseIter) finish(err error) {
+	a.err = err
+	if a.err == nil {
+		a.err = io.EOF
+	}
+	close(a.a)
+}
@@ -0,0 +1,93 @@
+package dawa
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+var adresse_haendelser_json_input = `
+[
+{"operation":"insert","tidspunkt":"2014-05-05T19:07:48.577Z","sekvensnummer":1,
+ "data":{"id":"0a3f50b7-6545-32b8-e044-0003ba298018","status":1,"oprettet":"2000-02-05T18:09:56.000","ændret":"2000-02-16T21:58:33.000","ikrafttrædelsesdato":"2000-02-05T18:09:56.000","adgangsadresseid":"0a3f508c-3307-32b8-e044-0003ba298018","etage":null,"dør":null,"kilde":2,"esdhreference":null,"journalnummer":null}},
+{"operation":"delete","tidspunkt":"2014-05-06T10:00:00.000Z","sekvensnummer":7,
+ "data":{"id":"0a3f50b7-6545-32b8-e044-0003ba298018","status":1,"oprettet":"2000-02-05T18:09:56.000","ændret":"2000-02-16T21:58:33.000","ikrafttrædelsesdato":null,"adgangsadresseid":"0a3f508c-3307-32b8-e044-0003ba298018","etage":"st","dør":"tv","kilde":2,"esdhreference":null,"journalnummer":null}}
+]
+`
+
+func TestImportHændelserJSON(t *testing.T) {
+	iter, err := ImportHændelserJSON(EntityAdresse, bytes.NewBufferString(adresse_haendelser_json_input))
+	if err != nil {
+		t.Fatalf("ImportHændelserJSON: %v", err)
+	}
+	h, err := iter.Next()
+	if err != nil {
+		t.Fatalf("ImportHændelserJSON, iter.Next(): %v", err)
+	}
+	if h.Entity != EntityAdresse || h.Operation != OperationInsert || h.Sekvensnummer != 1 {
+		t.Fatalf("unexpected hændelse: %#v", *h)
+	}
+	if h.Adresse == nil || h.AdgangsAdresse != nil {
+		t.Fatalf("unexpected payload: %#v", *h)
+	}
+	expect := ReplikeringAdresse{
+		ID:                  "0a3f50b7-6545-32b8-e044-0003ba298018",
+		Status:              1,
+		Oprettet:            MustParseTime("2000-02-05T18:09:56.000"),
+		Ændret:              MustParseTime("2000-02-16T21:58:33.000"),
+		Ikrafttrædelsesdato: MustParseTime("2000-02-05T18:09:56.000"),
+		AdgangsadresseID:    "0a3f508c-3307-32b8-e044-0003ba298018",
+		Kilde:               2,
+	}
+	if !reflect.DeepEqual(*h.Adresse, expect) {
+		t.Fatalf("value mismatch.\nGot:\n%#v\nExpected:\n%#v\n", *h.Adresse, expect)
+	}
+	if h.Key() != expect.ID {
+		t.Fatalf("unexpected key %q", h.Key())
+	}
+
+	h, err = iter.Next()
+	if err != nil {
+		t.Fatalf("ImportHændelserJSON, iter.Next(): %v", err)
+	}
+	if h.Operation != OperationDelete || h.Sekvensnummer != 7 || h.Adresse.Etage != "st" {
+		t.Fatalf("unexpected hændelse: %#v", *h)
+	}
+	_, err = iter.Next()
+	if err != io.EOF {
+		t.Fatalf("ImportHændelserJSON: Expected io.EOF, got:%v", err)
+	}
+}
+
+func TestImportHændelserJSONVejstykke(t *testing.T) {
+	input := `[{"operation":"update","tidspunkt":"2014-05-05T19:07:48.577Z","sekvensnummer":3,
+	"data":{"kommunekode":550,"kode":1,"oprettet":"2000-02-05T18:09:56.000","ændret":"2000-02-16T21:58:33.000","navn":"A Hansensvej","adresseringsnavn":"A Hansensvej"}}]`
+	iter, err := ImportHændelserJSON(EntityVejstykke, bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := iter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Vejstykke == nil || h.Key() != "0550-0001" {
+		t.Fatalf("unexpected hændelse: %#v", *h)
+	}
+	v := h.Vejstykke.Vejstykke()
+	if v.Kommune.Kode != "0550" || v.Kode != "0001" || v.Navn != "A Hansensvej" {
+		t.Fatalf("unexpected vejstykke: %#v", v)
+	}
+	_, err = ImportHændelserJSON(Entity("unknown"), bytes.NewBufferString(input))
+	if err == nil {
+		t.Fatal("expected error on unknown entity")
+	}
+}
+
+func TestHændelseQueryURL(t *testing.T) {
+	got := NewHændelseQuery(EntityAdgangsAdresse).SekvensnummerFra(10).SekvensnummerTil(20).URL()
+	expect := DefaultHost + "/replikering/adgangsadresser/haendelser?sekvensnummerfra=10&sekvensnummertil=20"
+	if got != expect {
+		t.Fatalf("Unexpected URL:\n     Was:\t%s\nExpected:\t%s", got, expect)
+	}
+}
@@ -0,0 +1,51 @@
+package dawa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Height will return the terrain height in meters above sea level (DVR90) at the given point.
+//
+// The point is given as [x,y], like Adgangspunkt.Koordinater, in WGS84 (longitude, latitude).
+// The height is looked up in Danmarks Højdemodel by DAWA.
+//
+// See documentation at http://dawa.aws.dk/hoejder
+func Height(ctx context.Context, point []float64) (float64, error) {
+	return HeightSrid(ctx, point, "")
+}
+
+// HeightSrid will return the terrain height in meters above sea level (DVR90) at the given point.
+//
+// The point is given as [x,y] in the coordinate system given by srid.
+// If srid is empty, WGS84 is assumed. Use "25832" for ETRS89/UTM32.
+func HeightSrid(ctx context.Context, point []float64, srid string) (float64, error) {
+	if len(point) < 2 {
+		return 0, fmt.Errorf("height: point must have 2 coordinates, got %d", len(point))
+	}
+	q := query{host: DefaultHost, path: "/hoejder"}
+	q.add(&textQuery{Name: "x", Values: []string{strconv.FormatFloat(point[0], 'f', -1, 64)}, Multi: false, Null: false})
+	q.add(&textQuery{Name: "y", Values: []string{strconv.FormatFloat(point[1], 'f', -1, 64)}, Multi: false, Null: false})
+	if srid != "" {
+		q.add(&textQuery{Name: "srid", Values: []string{srid}, Multi: false, Null: false})
+	}
+	resp, err := q.RequestContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Close()
+
+	var res struct {
+		Højde *float64 `json:"højde"`
+	}
+	err = json.NewDecoder(resp).Decode(&res)
+	if err != nil {
+		return 0, err
+	}
+	if res.Højde == nil {
+		return 0, fmt.Errorf("height: no height returned for point %v", point)
+	}
+	return *res.Højde, nil
+}
@@ -0,0 +1,63 @@
+package dawa
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHeight(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hoejder" {
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("x") != "12.5582458296225" || q.Get("y") != "55.6720594006065" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"højde": 6.2}`)
+	}))
+	defer ts.Close()
+	old := DefaultHost
+	DefaultHost = ts.URL
+	defer func() { DefaultHost = old }()
+
+	h, err := Height(context.Background(), []float64{12.5582458296225, 55.6720594006065})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h != 6.2 {
+		t.Fatalf("expected height 6.2, got %v", h)
+	}
+
+	_, err = Height(context.Background(), []float64{12.5})
+	if err == nil {
+		t.Fatal("expected error on short point")
+	}
+}
+
+func TestImportAdgangspunktHøjde(t *testing.T) {
+	input := `[{"id":"a","adgangspunkt":{"koordinater":[12.55,55.67],"højde":6.2}},{"id":"b","adgangspunkt":{"koordinater":[12.55,55.67],"højde":null}}]`
+	iter, err := ImportAdgangsAdresserJSON(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := iter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Adgangspunkt.Højde == nil || *a.Adgangspunkt.Højde != 6.2 {
+		t.Fatalf("unexpected height: %v", a.Adgangspunkt.Højde)
+	}
+	a, err = iter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Adgangspunkt.Højde != nil {
+		t.Fatalf("expected nil height, got %v", *a.Adgangspunkt.Højde)
+	}
+}
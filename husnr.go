@@ -0,0 +1,214 @@
+package dawa
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Husnr is a husnummer, which identifies an adgangsadresse among the adgangsadresser with the same vejnavn.
+// Husnummeret består af et tal 1-999 evt. suppleret af et stort bogstav A..Z, for eksempel "12B".
+//
+// Husnumre can be compared in natural order, so "2" < "2A" < "10".
+type Husnr string
+
+// ParseHusnr will parse and normalize a husnummer.
+// Spaces and leading zeros are removed and the letter is converted to upper case,
+// so "012 b" is returned as "12B".
+// An error is returned if the result is not a valid husnummer.
+func ParseHusnr(s string) (Husnr, error) {
+	s = strings.ToUpper(strings.Join(strings.Fields(s), ""))
+	h := Husnr(strings.TrimLeft(s, "0"))
+	n, b, ok := h.parse()
+	if !ok {
+		return "", fmt.Errorf("invalid husnr '%s'", s)
+	}
+	return Husnr(strconv.Itoa(n) + b), nil
+}
+
+// husnrValues returns the values with valid husnumre normalized.
+// Other values are returned unchanged.
+func husnrValues(s []string) []string {
+	res := make([]string, len(s))
+	for i, v := range s {
+		if h, err := ParseHusnr(v); err == nil {
+			v = string(h)
+		}
+		res[i] = v
+	}
+	return res
+}
+
+// parse returns the number and letter of the husnummer.
+func (h Husnr) parse() (n int, bogstav string, ok bool) {
+	s := string(h)
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 || i > 3 || len(s) > i+1 {
+		return 0, "", false
+	}
+	if i < len(s) && (s[i] < 'A' || s[i] > 'Z') {
+		return 0, "", false
+	}
+	n, _ = strconv.Atoi(s[:i])
+	if n < 1 {
+		return 0, "", false
+	}
+	return n, s[i:], true
+}
+
+// Valid returns true if the husnummer is a number 1-999, optionally followed by a letter A..Z.
+func (h Husnr) Valid() bool {
+	_, _, ok := h.parse()
+	return ok
+}
+
+// Nummer returns the number of the husnummer, for example 12 for "12B".
+// 0 is returned if the husnummer isn't valid.
+func (h Husnr) Nummer() int {
+	n, _, _ := h.parse()
+	return n
+}
+
+// Bogstav returns the letter of the husnummer, for example "B" for "12B".
+// An empty string is returned if there is no letter, or the husnummer isn't valid.
+func (h Husnr) Bogstav() string {
+	_, b, _ := h.parse()
+	return b
+}
+
+// Lige returns true if the number of the husnummer is even.
+// Danish streets usually have even numbers on one side and odd numbers on the other.
+func (h Husnr) Lige() bool {
+	n := h.Nummer()
+	return n > 0 && n%2 == 0
+}
+
+// Ulige returns true if the number of the husnummer is odd.
+func (h Husnr) Ulige() bool {
+	return h.Nummer()%2 == 1
+}
+
+// String returns the husnummer as a string.
+func (h Husnr) String() string {
+	return string(h)
+}
+
+// Less returns true if h is before o in natural order.
+func (h Husnr) Less(o Husnr) bool {
+	return CompareHusnr(h, o) < 0
+}
+
+// CompareHusnr compares two husnumre in natural order.
+// The result will be 0 if a==b, -1 if a < b, and +1 if a > b.
+//
+// Husnumre are ordered by number and then by letter, so "2" < "2A" < "10".
+// Invalid husnumre are placed after valid ones, ordered as strings.
+func CompareHusnr(a, b Husnr) int {
+	an, ab, aok := a.parse()
+	bn, bb, bok := b.parse()
+	switch {
+	case aok && !bok:
+		return -1
+	case !aok && bok:
+		return 1
+	case !aok && !bok:
+		return strings.Compare(string(a), string(b))
+	case an != bn:
+		if an < bn {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(ab, bb)
+}
+
+// SortHusnr sorts husnumre in natural order.
+func SortHusnr(h []Husnr) {
+	sort.Sort(husnrSlice(h))
+}
+
+type husnrSlice []Husnr
+
+func (h husnrSlice) Len() int           { return len(h) }
+func (h husnrSlice) Less(i, j int) bool { return h[i].Less(h[j]) }
+func (h husnrSlice) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+// HusnrSide selects the side of the street in a HusnrInterval.
+type HusnrSide int
+
+const (
+	HusnrBegge HusnrSide = iota // Both even and odd numbers.
+	HusnrLige                   // Even numbers only.
+	HusnrUlige                  // Odd numbers only.
+)
+
+// HusnrInterval is an interval of husnumre, for instance 2-14 even.
+// Both ends are included. Letters are considered, so 2-14 contains "14" but not "14A",
+// while 2-14A contains both.
+// An empty Fra or Til means that the interval is open in that end.
+type HusnrInterval struct {
+	Fra  Husnr
+	Til  Husnr
+	Side HusnrSide
+}
+
+// Contains returns true if the husnummer is in the interval.
+// Invalid husnumre are never contained.
+func (i HusnrInterval) Contains(h Husnr) bool {
+	if !h.Valid() {
+		return false
+	}
+	switch i.Side {
+	case HusnrLige:
+		if !h.Lige() {
+			return false
+		}
+	case HusnrUlige:
+		if !h.Ulige() {
+			return false
+		}
+	}
+	if i.Fra != "" && CompareHusnr(h, i.Fra) < 0 {
+		return false
+	}
+	if i.Til != "" && CompareHusnr(h, i.Til) > 0 {
+		return false
+	}
+	return true
+}
+
+// Husnumre returns all husnumre without letters in the interval, in natural order.
+// If the interval is open in the upper end, numbers up to 999 are returned.
+func (i HusnrInterval) Husnumre() []Husnr {
+	from, to := 1, 999
+	if i.Fra != "" {
+		from = i.Fra.Nummer()
+	}
+	if i.Til != "" {
+		to = i.Til.Nummer()
+	}
+	var res []Husnr
+	for n := from; n <= to && n > 0; n++ {
+		h := Husnr(strconv.Itoa(n))
+		if i.Contains(h) {
+			res = append(res, h)
+		}
+	}
+	return res
+}
+
+// String returns the interval as text, for example "2-14 lige".
+func (i HusnrInterval) String() string {
+	s := string(i.Fra) + "-" + string(i.Til)
+	switch i.Side {
+	case HusnrLige:
+		s += " lige"
+	case HusnrUlige:
+		s += " ulige"
+	}
+	return s
+}
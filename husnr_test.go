@@ -0,0 +1,73 @@
+package dawa
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHusnr(t *testing.T) {
+	valid := map[string]Husnr{"12": "12", "012 b": "12B", " 2a ": "2A", "999Z": "999Z", "1": "1"}
+	for in, want := range valid {
+		got, err := ParseHusnr(in)
+		if err != nil || got != want {
+			t.Errorf("ParseHusnr(%q): got %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "0", "A", "1000", "12AB", "12-14", "1Æ"} {
+		if _, err := ParseHusnr(in); err == nil {
+			t.Errorf("ParseHusnr(%q): expected error", in)
+		}
+	}
+	h := Husnr("12B")
+	if h.Nummer() != 12 || h.Bogstav() != "B" || !h.Lige() || h.Ulige() || !h.Valid() {
+		t.Fatalf("unexpected values for %q", h)
+	}
+	if Husnr("7").Lige() || !Husnr("7").Ulige() || Husnr("x").Lige() || Husnr("x").Ulige() {
+		t.Fatal("unexpected side")
+	}
+}
+
+func TestSortHusnr(t *testing.T) {
+	h := []Husnr{"10", "2A", "x", "2", "1", "10B", "3"}
+	SortHusnr(h)
+	want := []Husnr{"1", "2", "2A", "3", "10", "10B", "x"}
+	if !reflect.DeepEqual(h, want) {
+		t.Fatalf("got %v, want %v", h, want)
+	}
+	if CompareHusnr("4", "4") != 0 || CompareHusnr("4A", "4") != 1 || !Husnr("9").Less("10") {
+		t.Fatal("unexpected comparison")
+	}
+}
+
+func TestHusnrInterval(t *testing.T) {
+	i := HusnrInterval{Fra: "2", Til: "14", Side: HusnrLige}
+	for h, want := range map[Husnr]bool{"2": true, "4A": true, "14": true, "14A": false, "3": false, "16": false, "1": false, "x": false} {
+		if i.Contains(h) != want {
+			t.Errorf("%v contains %q: expected %v", i, h, want)
+		}
+	}
+	want := []Husnr{"2", "4", "6", "8", "10", "12", "14"}
+	if got := i.Husnumre(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if s := i.String(); s != "2-14 lige" {
+		t.Fatalf("unexpected string %q", s)
+	}
+	if got := (HusnrInterval{Fra: "995", Side: HusnrUlige}).Husnumre(); !reflect.DeepEqual(got, []Husnr{"995", "997", "999"}) {
+		t.Fatalf("unexpected result %v", got)
+	}
+	if !(HusnrInterval{Til: "5A"}).Contains("5A") {
+		t.Fatal("expected open interval to contain 5A")
+	}
+}
+
+func TestHusnrQuery(t *testing.T) {
+	u := NewAdresseQuery().Husnr("012 b", "4").HusnrInterval(HusnrInterval{Fra: "2", Til: "14"}).URL()
+	if u != DefaultHost+"/adresser?husnr=12B|4&husnrfra=2&husnrtil=14" {
+		t.Fatalf("unexpected url %s", u)
+	}
+	u = NewAdgangsAdresseQuery().HusnrInterval(HusnrInterval{Til: "14"}).URL()
+	if u != DefaultHost+"/adgangsadresser?husnrtil=14" {
+		t.Fatalf("unexpected url %s", u)
+	}
+}
@@ -0,0 +1,474 @@
+package dawa
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Index is an in-memory index of adresser and adgangsadresser,
+// that allows fast lookups without contacting the server.
+//
+// Adresser are linked to their adgangsadresse, so if both are loaded,
+// the Adgangsadresse field of adresser will contain the full adgangsadresse,
+// no matter in which order they were added.
+//
+// The index is not safe for concurrent modification, but
+// lookups can be done concurrently when it is no longer modified.
+// Objects returned by lookups are shared with the index, and should not be modified.
+//
+// Use NewIndex() to get an initialized object.
+// Example:
+//			iter, err := dawa.ImportAdgangsAdresserCSV(file)
+//			ix := dawa.NewIndex()
+//			err = ix.LoadAdgangsAdresser(iter)
+//			a := ix.AdgangsAdresseVej(101, 4459, "12B")
+type Index struct {
+	adgangsadresser map[string]*AdgangsAdresse
+	adresser        map[string]*Adresse
+
+	// Adresser by the ID of their adgangsadresse.
+	adresserAA map[string][]*Adresse
+
+	// Adgangsadresser by kommunekode and vejkode (with empty husnr), and by postnummer.
+	vejstykker map[indexVejKey][]*AdgangsAdresse
+	postnumre  map[int][]*AdgangsAdresse
+
+	// Kommuner by kommunekode, with the number of adgangsadresser in the kommune.
+	kommuner map[int]*indexKommune
+
+	kvh        map[string]*AdgangsAdresse
+	kvhx       map[string]*Adresse
+	vejHusnr   map[indexVejKey]*AdgangsAdresse
+	postHusnr  map[indexPostKey]*AdgangsAdresse
+	postAdress map[indexPostKey]*Adresse
+}
+
+// indexVejKey is a key of kommunekode, vejkode and husnr.
+type indexVejKey struct {
+	kommune, vej int
+	husnr        string
+}
+
+// indexKommune is a kommune built from the adgangsadresser in the index.
+type indexKommune struct {
+	kommune Kommune
+	n       int
+}
+
+// indexPostKey is a key of postnummer, vejnavn, husnr, etage and dør.
+type indexPostKey struct {
+	postnr                     int
+	vejnavn, husnr, etage, dør string
+}
+
+// NewIndex returns a new, empty Index.
+func NewIndex() *Index {
+	return &Index{
+		adgangsadresser: make(map[string]*AdgangsAdresse),
+		adresser:        make(map[string]*Adresse),
+		adresserAA:      make(map[string][]*Adresse),
+		vejstykker:      make(map[indexVejKey][]*AdgangsAdresse),
+		postnumre:       make(map[int][]*AdgangsAdresse),
+		kommuner:        make(map[int]*indexKommune),
+		kvh:             make(map[string]*AdgangsAdresse),
+		kvhx:            make(map[string]*Adresse),
+		vejHusnr:        make(map[indexVejKey]*AdgangsAdresse),
+		postHusnr:       make(map[indexPostKey]*AdgangsAdresse),
+		postAdress:      make(map[indexPostKey]*Adresse),
+	}
+}
+
+// LoadAdgangsAdresser will add all adgangsadresser from the iterator to the index.
+// The iterator is read until io.EOF is returned.
+func (ix *Index) LoadAdgangsAdresser(iter *AdgangsAdresseIter) error {
+	for {
+		a, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ix.AddAdgangsAdresse(*a)
+	}
+}
+
+// LoadAdresser will add all adresser from the iterator to the index.
+// The iterator is read until io.EOF is returned.
+func (ix *Index) LoadAdresser(iter *AdresseIter) error {
+	for {
+		a, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ix.AddAdresse(*a)
+	}
+}
+
+// AddAdgangsAdresse will add an adgangsadresse to the index.
+// If an adgangsadresse with the same ID exists it is replaced.
+func (ix *Index) AddAdgangsAdresse(a AdgangsAdresse) {
+	if old := ix.adgangsadresser[a.ID]; old != nil {
+		ix.removeAdgangsAdresseKeys(old)
+	}
+	p := &a
+	ix.adgangsadresser[a.ID] = p
+	ix.addAdgangsAdresseKeys(p)
+
+	// Update adresser on this adgangsadresse.
+	for _, ad := range ix.adresserAA[a.ID] {
+		ix.removeAdresseKeys(ad)
+		ad.Adgangsadresse = a
+		ix.addAdresseKeys(ad)
+	}
+}
+
+// AddAdresse will add an adresse to the index.
+// If an adresse with the same ID exists it is replaced.
+// If the adgangsadresse of the adresse is in the index, it will be used
+// as Adgangsadresse. Otherwise the supplied Adgangsadresse is kept, and
+// indexed as well, if it has an ID.
+func (ix *Index) AddAdresse(a Adresse) {
+	if old := ix.adresser[a.ID]; old != nil {
+		ix.removeAdresseKeys(old)
+		ix.unlinkAdresse(old)
+	}
+	aaID := a.Adgangsadresse.ID
+	if aa := ix.adgangsadresser[aaID]; aa != nil {
+		a.Adgangsadresse = *aa
+	} else if aaID != "" && a.Adgangsadresse.Husnr != "" {
+		ix.AddAdgangsAdresse(a.Adgangsadresse)
+	}
+	p := &a
+	ix.adresser[a.ID] = p
+	if aaID != "" {
+		ix.adresserAA[aaID] = append(ix.adresserAA[aaID], p)
+	}
+	ix.addAdresseKeys(p)
+}
+
+// Len returns the number of adgangsadresser and adresser in the index.
+func (ix *Index) Len() (adgangsadresser, adresser int) {
+	return len(ix.adgangsadresser), len(ix.adresser)
+}
+
+// AdgangsAdresse returns the adgangsadresse with the specified ID.
+// nil is returned if it is not in the index.
+func (ix *Index) AdgangsAdresse(id string) *AdgangsAdresse {
+	return ix.adgangsadresser[id]
+}
+
+// Adresse returns the adresse with the specified ID.
+// nil is returned if it is not in the index.
+func (ix *Index) Adresse(id string) *Adresse {
+	return ix.adresser[id]
+}
+
+// AdgangsAdresseKVH returns the adgangsadresse with the specified KVH-nøgle, for example "010144590012".
+// The key is normalized, so padding with zeros or underscores and the case of the husnr don't matter.
+// nil is returned if it is not in the index.
+func (ix *Index) AdgangsAdresseKVH(kvh string) *AdgangsAdresse {
+	return ix.kvh[indexNormalizeKVH(kvh)]
+}
+
+// AdresseKVHX returns the adresse with the specified KVHX-nøgle, for example "01014459__12_01__tv".
+// The key is normalized, so padding with zeros or underscores and the case of the husnr don't matter.
+// nil is returned if it is not in the index.
+func (ix *Index) AdresseKVHX(kvhx string) *Adresse {
+	return ix.kvhx[indexNormalizeKVHX(kvhx)]
+}
+
+// AdgangsAdresseVej returns the adgangsadresse with the specified kommunekode, vejkode and husnr.
+// nil is returned if it is not in the index.
+func (ix *Index) AdgangsAdresseVej(kommunekode, vejkode int, husnr string) *AdgangsAdresse {
+	return ix.vejHusnr[indexVejKey{kommune: kommunekode, vej: vejkode, husnr: indexNormalizeHusnr(husnr)}]
+}
+
+// AdgangsAdressePostnr returns the adgangsadresse with the specified postnummer, vejnavn and husnr.
+// Vejnavn and husnr are not case sensitive.
+// nil is returned if it is not in the index.
+func (ix *Index) AdgangsAdressePostnr(postnr int, vejnavn, husnr string) *AdgangsAdresse {
+	return ix.postHusnr[newIndexPostKey(postnr, vejnavn, husnr, "", "")]
+}
+
+// AdressePostnr returns the adresse with the specified postnummer, vejnavn, husnr, etage and dør.
+// Use empty strings for etage and dør if the adresse has none.
+// Vejnavn, husnr, etage and dør are not case sensitive.
+// Husnr, etage and dør are normalized, so "012B", "st." and "th." match "12B", "st" and "th".
+// nil is returned if it is not in the index.
+func (ix *Index) AdressePostnr(postnr int, vejnavn, husnr, etage, dør string) *Adresse {
+	return ix.postAdress[newIndexPostKey(postnr, vejnavn, husnr, etage, dør)]
+}
+
+// AdresserAdgangsAdresse returns all adresser on the adgangsadresse with the specified ID.
+// The returned slice is not changed by later updates of the index, and must not be modified.
+func (ix *Index) AdresserAdgangsAdresse(id string) []*Adresse {
+	return ix.adresserAA[id]
+}
+
+// AdgangsAdresserVejstykke returns all adgangsadresser on the vejstykke with the specified kommunekode and vejkode.
+// The returned slice is not changed by later updates of the index, and must not be modified.
+func (ix *Index) AdgangsAdresserVejstykke(kommunekode, vejkode int) []*AdgangsAdresse {
+	return ix.vejstykker[indexVejKey{kommune: kommunekode, vej: vejkode}]
+}
+
+// AdgangsAdresserPostnr returns all adgangsadresser in the specified postnummer.
+// The returned slice is not changed by later updates of the index, and must not be modified.
+func (ix *Index) AdgangsAdresserPostnr(postnr int) []*AdgangsAdresse {
+	return ix.postnumre[postnr]
+}
+
+// Kommune returns the kommune with the specified kommunekode,
+// built from the adgangsadresser in the index.
+// Only the kode, navn and regionskode are filled.
+// If no adgangsadresser are in the kommune, nil is returned.
+func (ix *Index) Kommune(kommunekode int) *Kommune {
+	k := ix.kommuner[kommunekode]
+	if k == nil {
+		return nil
+	}
+	res := k.kommune
+	return &res
+}
+
+func (ix *Index) addAdgangsAdresseKeys(a *AdgangsAdresse) {
+	if kode, err := strconv.Atoi(a.Kommune.Kode); err == nil {
+		k := ix.kommuner[kode]
+		if k == nil {
+			k = &indexKommune{}
+			ix.kommuner[kode] = k
+		}
+		// Not all adgangsadresser have a region, so keep the first that has one.
+		if k.n == 0 || (k.kommune.Regionskode == "" && a.Region.Kode != "") {
+			k.kommune = Kommune{KommuneRef: KommuneRef{Kode: a.Kommune.Kode, Navn: a.Kommune.Navn}, Regionskode: a.Region.Kode}
+		}
+		k.n++
+	}
+	if k := indexKVH(a); k != "" {
+		ix.kvh[k] = a
+	}
+	if k, ok := newIndexVejKey(a); ok {
+		ix.vejHusnr[k] = a
+		k.husnr = ""
+		ix.vejstykker[k] = append(ix.vejstykker[k], a)
+	}
+	if k, ok := adgangsAdressePostKey(a); ok {
+		ix.postHusnr[k] = a
+	}
+	if p, err := strconv.Atoi(a.Postnummer.Nr); err == nil {
+		ix.postnumre[p] = append(ix.postnumre[p], a)
+	}
+}
+
+func (ix *Index) removeAdgangsAdresseKeys(a *AdgangsAdresse) {
+	if kode, err := strconv.Atoi(a.Kommune.Kode); err == nil {
+		if k := ix.kommuner[kode]; k != nil {
+			if k.n--; k.n == 0 {
+				delete(ix.kommuner, kode)
+			}
+		}
+	}
+	if k := indexKVH(a); k != "" && ix.kvh[k] == a {
+		delete(ix.kvh, k)
+	}
+	if k, ok := newIndexVejKey(a); ok {
+		if ix.vejHusnr[k] == a {
+			delete(ix.vejHusnr, k)
+		}
+		k.husnr = ""
+		if list := indexRemove(ix.vejstykker[k], a); len(list) > 0 {
+			ix.vejstykker[k] = list
+		} else {
+			delete(ix.vejstykker, k)
+		}
+	}
+	if k, ok := adgangsAdressePostKey(a); ok && ix.postHusnr[k] == a {
+		delete(ix.postHusnr, k)
+	}
+	if p, err := strconv.Atoi(a.Postnummer.Nr); err == nil {
+		if list := indexRemove(ix.postnumre[p], a); len(list) > 0 {
+			ix.postnumre[p] = list
+		} else {
+			delete(ix.postnumre, p)
+		}
+	}
+}
+
+// indexRemove returns the list with a removed.
+// A new slice is returned, since the list may be held by callers.
+func indexRemove(list []*AdgangsAdresse, a *AdgangsAdresse) []*AdgangsAdresse {
+	for i, v := range list {
+		if v == a {
+			res := make([]*AdgangsAdresse, 0, len(list)-1)
+			res = append(res, list[:i]...)
+			return append(res, list[i+1:]...)
+		}
+	}
+	return list
+}
+
+func (ix *Index) addAdresseKeys(a *Adresse) {
+	if k := indexKVHX(a); k != "" {
+		ix.kvhx[k] = a
+	}
+	if k, ok := adressePostKey(a); ok {
+		ix.postAdress[k] = a
+	}
+}
+
+func (ix *Index) removeAdresseKeys(a *Adresse) {
+	if k := indexKVHX(a); k != "" && ix.kvhx[k] == a {
+		delete(ix.kvhx, k)
+	}
+	if k, ok := adressePostKey(a); ok && ix.postAdress[k] == a {
+		delete(ix.postAdress, k)
+	}
+}
+
+// unlinkAdresse removes the adresse from the list of its adgangsadresse.
+func (ix *Index) unlinkAdresse(a *Adresse) {
+	id := a.Adgangsadresse.ID
+	list := ix.adresserAA[id]
+	for i, v := range list {
+		if v == a {
+			// Copy the list, since it may be held by callers.
+			res := make([]*Adresse, 0, len(list)-1)
+			res = append(res, list[:i]...)
+			list = append(res, list[i+1:]...)
+			break
+		}
+	}
+	if len(list) == 0 {
+		delete(ix.adresserAA, id)
+		return
+	}
+	ix.adresserAA[id] = list
+}
+
+// indexNormalize returns s in lower case with surrounding and repeated spaces removed.
+func indexNormalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// indexNormalizeHusnr returns the husnr normalized by ParseHusnr,
+// so "012B" and "12b" give the same key.
+// Values that cannot be parsed are normalized with indexNormalize.
+func indexNormalizeHusnr(s string) string {
+	h, err := ParseHusnr(s)
+	if err != nil {
+		return indexNormalize(s)
+	}
+	return string(h)
+}
+
+// indexNormalizeEtage returns the etage normalized by ParseEtage,
+// so "st." and "st" give the same key.
+// Values that cannot be parsed are normalized with indexNormalize.
+func indexNormalizeEtage(s string) string {
+	e, err := ParseEtage(s)
+	if err != nil {
+		return indexNormalize(s)
+	}
+	return string(e)
+}
+
+// indexNormalizeDør returns the dør normalized by ParseDør,
+// so "th." and "th" give the same key.
+// Values that cannot be parsed are normalized with indexNormalize.
+func indexNormalizeDør(s string) string {
+	d, err := ParseDør(s)
+	if err != nil {
+		return indexNormalize(s)
+	}
+	return string(d)
+}
+
+func newIndexPostKey(postnr int, vejnavn, husnr, etage, dør string) indexPostKey {
+	return indexPostKey{
+		postnr:  postnr,
+		vejnavn: indexNormalize(vejnavn),
+		husnr:   indexNormalizeHusnr(husnr),
+		etage:   indexNormalizeEtage(etage),
+		dør:     indexNormalizeDør(dør),
+	}
+}
+
+func newIndexVejKey(a *AdgangsAdresse) (indexVejKey, bool) {
+	k, err := strconv.Atoi(a.Kommune.Kode)
+	if err != nil {
+		return indexVejKey{}, false
+	}
+	v, err := strconv.Atoi(a.Vejstykke.Kode)
+	if err != nil {
+		return indexVejKey{}, false
+	}
+	return indexVejKey{kommune: k, vej: v, husnr: indexNormalizeHusnr(string(a.Husnr))}, true
+}
+
+func adgangsAdressePostKey(a *AdgangsAdresse) (indexPostKey, bool) {
+	p, err := strconv.Atoi(a.Postnummer.Nr)
+	if err != nil || a.Vejstykke.Navn == "" {
+		return indexPostKey{}, false
+	}
+	return newIndexPostKey(p, a.Vejstykke.Navn, string(a.Husnr), "", ""), true
+}
+
+func adressePostKey(a *Adresse) (indexPostKey, bool) {
+	k, ok := adgangsAdressePostKey(&a.Adgangsadresse)
+	if !ok {
+		return k, false
+	}
+	k.etage = indexNormalizeEtage(string(a.Etage))
+	k.dør = indexNormalizeDør(string(a.Dør))
+	return k, true
+}
+
+// indexKVH returns the KVH-nøgle of the adgangsadresse.
+// If the Kvh field isn't set, it is calculated from kommunekode, vejkode and husnr.
+func indexKVH(a *AdgangsAdresse) string {
+	if a.Kvh != "" {
+		return indexNormalizeKVH(a.Kvh)
+	}
+	k, err := NewKVH(a)
+	if err != nil {
+		return ""
+	}
+	return k.String()
+}
+
+// indexKVHX returns the KVHX-nøgle of the adresse.
+// If the Kvhx field isn't set, it is calculated from the adgangsadresse, etage and dør.
+func indexKVHX(a *Adresse) string {
+	if a.Kvhx != "" {
+		return indexNormalizeKVHX(a.Kvhx)
+	}
+	k, err := NewKVHX(a)
+	if err != nil {
+		return ""
+	}
+	return k.String()
+}
+
+// indexNormalizeKVH returns the KVH-nøgle in the format returned by KVH.String().
+// Keys that cannot be parsed are returned unchanged.
+func indexNormalizeKVH(kvh string) string {
+	k, err := ParseKVH(kvh)
+	if err != nil {
+		return kvh
+	}
+	return k.String()
+}
+
+// indexNormalizeKVHX returns the KVHX-nøgle in the format returned by KVHX.String().
+// Keys that cannot be parsed are returned unchanged.
+func indexNormalizeKVHX(kvhx string) string {
+	k, err := ParseKVHX(kvhx)
+	if err != nil {
+		return kvhx
+	}
+	return k.String()
+}
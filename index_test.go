@@ -0,0 +1,162 @@
+package dawa
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var index_aa_input = `[
+{"id":"aa1","kvh":"01014459__12","husnr":"12","kommune":{"kode":"0101","navn":"København"},"vejstykke":{"kode":"4459","navn":"Testvej"},"postnummer":{"nr":"2400","navn":"København NV"},"supplerendebynavn":"Test"},
+{"id":"aa2","husnr":"12B","kommune":{"kode":"0101"},"vejstykke":{"kode":"4459","navn":"Testvej"},"postnummer":{"nr":"2400"}}
+]`
+
+var index_adresse_input = `[
+{"id":"ad1","etage":"1","dør":"tv","adgangsadresse":{"id":"aa1"}},
+{"id":"ad2","etage":"st","dør":"","kvhx":"01014459_12B_st____","adgangsadresse":{"id":"aa2","husnr":"12B","kommune":{"kode":"0101"},"vejstykke":{"kode":"4459","navn":"Testvej"},"postnummer":{"nr":"2400"}}}
+]`
+
+func TestIndex(t *testing.T) {
+	ix := NewIndex()
+	// Add adresser before adgangsadresser to test that they are linked.
+	ad, err := ImportAdresserJSON(bytes.NewBufferString(index_adresse_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ix.LoadAdresser(ad)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := ix.AdressePostnr(2400, "testvej", "12", "1", "TV"); a != nil {
+		t.Fatal("adresse without adgangsadresse data should not be indexed by postnr")
+	}
+	aa, err := ImportAdgangsAdresserJSON(bytes.NewBufferString(index_aa_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ix.LoadAdgangsAdresser(aa)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, m := ix.Len(); n != 2 || m != 2 {
+		t.Fatalf("unexpected size: %d, %d", n, m)
+	}
+
+	if a := ix.AdgangsAdresse("aa1"); a == nil || a.Husnr != "12" {
+		t.Fatalf("unexpected adgangsadresse: %#v", a)
+	}
+	if a := ix.AdgangsAdresseKVH("01014459__12"); a == nil || a.ID != "aa1" {
+		t.Fatalf("unexpected adgangsadresse by kvh: %#v", a)
+	}
+	if a := ix.AdgangsAdresseKVH("01014459_12B"); a == nil || a.ID != "aa2" {
+		t.Fatalf("calculated kvh not found: %#v", a)
+	}
+	if a := ix.AdgangsAdresseKVH("010144590012"); a == nil || a.ID != "aa1" {
+		t.Fatalf("zero padded kvh not found: %#v", a)
+	}
+	if a := ix.AdgangsAdresseVej(101, 4459, "12b"); a == nil || a.ID != "aa2" {
+		t.Fatalf("unexpected adgangsadresse by vej: %#v", a)
+	}
+	if a := ix.AdgangsAdressePostnr(2400, "TESTVEJ", "12"); a == nil || a.ID != "aa1" {
+		t.Fatalf("unexpected adgangsadresse by postnr: %#v", a)
+	}
+
+	a := ix.Adresse("ad1")
+	if a == nil || a.Adgangsadresse.SupplerendeBynavn != "Test" {
+		t.Fatalf("adresse not linked to adgangsadresse: %#v", a)
+	}
+	if a := ix.AdressePostnr(2400, "testvej", "12", "1", "TV"); a == nil || a.ID != "ad1" {
+		t.Fatalf("unexpected adresse by postnr: %#v", a)
+	}
+	if a := ix.AdresseKVHX("01014459__12__1__tv"); a == nil || a.ID != "ad1" {
+		t.Fatalf("unexpected adresse by kvhx: %#v", a)
+	}
+	if a := ix.AdresseKVHX("01014459_12B_st____"); a == nil || a.ID != "ad2" {
+		t.Fatalf("unexpected adresse by kvhx: %#v", a)
+	}
+	if a := ix.AdresseKVHX("010144590012_01__tv"); a == nil || a.ID != "ad1" {
+		t.Fatalf("zero padded kvhx not found: %#v", a)
+	}
+	if l := ix.AdresserAdgangsAdresse("aa2"); len(l) != 1 || l[0].ID != "ad2" {
+		t.Fatalf("unexpected adresser on adgangsadresse: %#v", l)
+	}
+	// Husnr, etage and dør are normalized on lookup.
+	if a := ix.AdgangsAdresseVej(101, 4459, "012B"); a == nil || a.ID != "aa2" {
+		t.Fatalf("zero padded husnr not found by vej: %#v", a)
+	}
+	if a := ix.AdgangsAdressePostnr(2400, "testvej", "012 b"); a == nil || a.ID != "aa2" {
+		t.Fatalf("zero padded husnr not found by postnr: %#v", a)
+	}
+	if a := ix.AdressePostnr(2400, "testvej", "012B", "st.", ""); a == nil || a.ID != "ad2" {
+		t.Fatalf("normalized etage not found by postnr: %#v", a)
+	}
+	if a := ix.AdressePostnr(2400, "testvej", "012", "01", "tv."); a == nil || a.ID != "ad1" {
+		t.Fatalf("zero padded etage not found by postnr: %#v", a)
+	}
+
+	// Replacing an adresse must remove the old keys.
+	ix.AddAdresse(Adresse{ID: "ad1", Etage: "2", Dør: "th", Adgangsadresse: AdgangsAdresse{ID: "aa1"}})
+	if a := ix.AdressePostnr(2400, "testvej", "12", "1", "tv"); a != nil {
+		t.Fatal("old key was not removed")
+	}
+	if a := ix.AdressePostnr(2400, "testvej", "12", "2", "th"); a == nil || a.ID != "ad1" {
+		t.Fatalf("unexpected adresse by postnr: %#v", a)
+	}
+	if a := ix.AdressePostnr(2400, "testvej", "12", "2.", "th."); a == nil || a.ID != "ad1" {
+		t.Fatalf("normalized dør not found by postnr: %#v", a)
+	}
+	if l := ix.AdresserAdgangsAdresse("aa1"); len(l) != 1 {
+		t.Fatalf("expected 1 adresse on adgangsadresse, got %d", len(l))
+	}
+	// Lists returned earlier are not changed by updates.
+	ix.AddAdresse(Adresse{ID: "ad3", Etage: "1", Adgangsadresse: AdgangsAdresse{ID: "aa2"}})
+	held := ix.AdresserAdgangsAdresse("aa2")
+	ix.AddAdresse(Adresse{ID: "ad2", Adgangsadresse: AdgangsAdresse{ID: "aa1"}})
+	if len(held) != 2 || held[0].ID != "ad2" || held[1].ID != "ad3" {
+		t.Fatalf("returned list was changed: %v, %v", held[0].ID, held[1].ID)
+	}
+	if l := ix.AdresserAdgangsAdresse("aa2"); len(l) != 1 || l[0].ID != "ad3" {
+		t.Fatalf("unexpected adresser on adgangsadresse: %#v", l)
+	}
+	if ix.Adresse("missing") != nil || ix.AdgangsAdresse("missing") != nil {
+		t.Fatal("expected nil on missing id")
+	}
+}
+
+func TestIndexCSVDøre(t *testing.T) {
+	lines := strings.Split(csv_data, "\n")
+	row := lines[1]
+	var rows []string
+	for i, d := range []string{"tv", "th"} {
+		r := strings.Replace(row, "0a3f50b7-6545", fmt.Sprintf("0a3f50b7-654%d", i), 1)
+		r = strings.Replace(r, ",6,,,Vråby,", ",6,1,"+strings.ToUpper(d)+",Vråby,", 1)
+		r = strings.Replace(r, "05500001___6_______", "05500001___6__1__"+d, 1)
+		rows = append(rows, r)
+	}
+	iter, err := ImportAdresserCSV(strings.NewReader(lines[0] + "\n" + strings.Join(rows, "\n") + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ix := NewIndex()
+	err = ix.LoadAdresser(iter.ValidateTo(func(r interface{}, err error) { t.Errorf("unexpected invalid record: %v", err) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, n := ix.Len(); n != 2 {
+		t.Fatalf("expected 2 adresser, got %d", n)
+	}
+	for i, d := range []string{"tv", "th"} {
+		id := fmt.Sprintf("0a3f50b7-654%d-32b8-e044-0003ba298018", i)
+		a := ix.AdressePostnr(6792, "A Hansensvej", "6", "1", d)
+		if a == nil || a.ID != id || a.Dør != Dør(d) || a.Etage != "1" {
+			t.Fatalf("%s: unexpected adresse by postnr: %#v", d, a)
+		}
+		if err := a.CheckKVHX(); err != nil {
+			t.Fatalf("%s: %v", d, err)
+		}
+		if a := ix.AdresseKVHX("05500001___6__1__" + d); a == nil || a.ID != id {
+			t.Fatalf("%s: unexpected adresse by kvhx: %#v", d, a)
+		}
+	}
+}
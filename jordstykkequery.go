@@ -0,0 +1,329 @@
+package dawa
+
+import (
+	"io"
+	"strconv"
+)
+
+// JordstykkeQuery is a new query for 'jordstykke' objects for searching DAWA.
+// Use NewJordstykkeQuery() or NewJordstykkeComplete() to get an initialized object.
+// Example:
+//			// Search for matrikelnr "377" in ejerlav 2000174
+//			item, err := dawa.NewJordstykkeQuery().Ejerlavkode("2000174").Matrikelnr("377").First()
+//
+//			// If err is nil, we go a result
+//			if err == nil {
+//				fmt.Printf("Got item:%+v\n", item)
+//			}
+type JordstykkeQuery struct {
+	queryGeoJSON
+}
+
+// NewJordstykkeQuery returns a new query for 'jordstykke' objects for searching DAWA.
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func NewJordstykkeQuery() *JordstykkeQuery {
+	return &JordstykkeQuery{queryGeoJSON: queryGeoJSON{query: query{host: DefaultHost, path: "/jordstykker"}}}
+}
+
+// NewJordstykkeComplete returns a new autocomplete query for 'jordstykke' objects for searching DAWA.
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func NewJordstykkeComplete() *JordstykkeQuery {
+	return &JordstykkeQuery{queryGeoJSON: queryGeoJSON{query: query{host: DefaultHost, path: "/jordstykker/autocomplete"}}}
+}
+
+// GetJordstykke will return a single Jordstykke with the specified ejerlavkode and matrikelnr.
+// Will return (nil, io.EOF) if there is no results.
+func GetJordstykke(ejerlavkode int, matrikelnr string) (*Jordstykke, error) {
+	return NewJordstykkeQuery().Ejerlavkode(strconv.Itoa(ejerlavkode)).Matrikelnr(matrikelnr).First()
+}
+
+// GetJordstykkeReverse will return the Jordstykke that contains the point (x,y).
+//
+// 	* x: X koordinat. (Hvis ETRS89/UTM32 anvendes angives øst-værdien.) Hvis WGS84/geografisk anvendex angives bredde-værdien.
+// 	* y: Y koordinat. (Hvis ETRS89/UTM32 anvendes angives nord-værdien.) Hvis WGS84/geografisk anvendex angives længde-værdien.
+//	* srid: Angiver SRID for det koordinatsystem, som geospatiale parametre er angivet i. Default er 4326 (WGS84). Leave this empty for default value
+//
+// Will return (nil, io.EOF) if there is no results.
+func GetJordstykkeReverse(x, y float64, srid string) (*Jordstykke, error) {
+	iter, err := NewReverseQuery("jordstykker", x, y, srid)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	return iter.NextJordstykke()
+}
+
+// Iter will return an iterator that allows you to read the results
+// one by one.
+//
+// The results will not contain the geometry of the jordstykker. Use IterGeometri() for that.
+func (q JordstykkeQuery) Iter() (*JordstykkeIter, error) {
+	resp, err := q.NoFormat().Request()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := ImportJordstykkerJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+	iter.AddCloser(resp)
+	return iter, nil
+}
+
+// IterGeometri will return an iterator that allows you to read the results
+// one by one. The results are requested as GeoJSON, so the geometry of each item is included.
+func (q JordstykkeQuery) IterGeometri() (*JordstykkeIter, error) {
+	c := q
+	c.query = q.query.clone()
+	c.Add("format", "geojson")
+	resp, err := c.NoFormat().Request()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := ImportJordstykkerGeoJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+	iter.AddCloser(resp)
+	return iter, nil
+}
+
+// All returns all results as an array.
+func (q JordstykkeQuery) All() ([]Jordstykke, error) {
+	resp, err := q.NoFormat().Request()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	ret := make([]Jordstykke, 0)
+	iter, err := ImportJordstykkerJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		a, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if a != nil {
+			ret = append(ret, *a)
+		}
+	}
+	return ret, nil
+}
+
+// First will return the first result from a query.
+// Note the entire query is executed, so only use this if you expect a few results.
+//
+// Will return (nil, io.EOF) if there is no results.
+func (q JordstykkeQuery) First() (*Jordstykke, error) {
+	resp, err := q.NoFormat().Request()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	iter, err := ImportJordstykkerJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := iter.Next()
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Q will add a parameter for 'q' to the JordstykkeQuery.
+//
+// Søgetekst. Der søges i matrikelnr og ejerlavets navn.
+// Alle ord i søgeteksten skal matche. Wildcard * er tilladt i slutningen af hvert ord.
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Q(s string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "q", Values: []string{s}})
+	return q
+}
+
+// Ejerlavkode will add a parameter for 'ejerlavkode' to the JordstykkeQuery.
+//
+// Koden på det matrikulære ejerlav som jordstykket ligger i. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Ejerlavkode(s ...string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "ejerlavkode", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Matrikelnr will add a parameter for 'matrikelnr' to the JordstykkeQuery.
+//
+// Matrikelnummer. Unikt indenfor et ejerlav. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Matrikelnr(s ...string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "matrikelnr", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Kommunekode will add a parameter for 'kommunekode' to the JordstykkeQuery.
+//
+// Kommunekoden for den kommune som jordstykket skal ligge i. 4 cifre. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Kommunekode(s ...string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "kommunekode", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Regionskode will add a parameter for 'regionskode' to the JordstykkeQuery.
+//
+// Find de jordstykker som ligger indenfor regionen angivet ved regionkoden. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Regionskode(s ...string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "regionskode", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Sognekode will add a parameter for 'sognekode' to the JordstykkeQuery.
+//
+// Find de jordstykker som ligger indenfor sognet angivet ved sognkoden. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Sognekode(s ...string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "sognekode", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Retskredskode will add a parameter for 'retskredskode' to the JordstykkeQuery.
+//
+// Find de jordstykker som ligger indenfor retskredsen angivet ved retskredskoden. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Retskredskode(s ...string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "retskredskode", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Esrejendomsnr will add a parameter for 'esrejendomsnr' to the JordstykkeQuery.
+//
+// ESR Ejendomsnummer. Indtil 7 cifre. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Esrejendomsnr(s ...string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "esrejendomsnr", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Sfeejendomsnr will add a parameter for 'sfeejendomsnr' to the JordstykkeQuery.
+//
+// Nummer for det samlede faste ejendom. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Sfeejendomsnr(s ...string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "sfeejendomsnr", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Bfenummer will add a parameter for 'bfenummer' to the JordstykkeQuery.
+//
+// BFE-nummer for det samlede faste ejendom. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Bfenummer(s ...string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "bfenummer", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// FeatureID will add a parameter for 'featureid' to the JordstykkeQuery.
+//
+// Jordstykkets featureid. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) FeatureID(s ...string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "featureid", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Moderjordstykke will add a parameter for 'moderjordstykke' to the JordstykkeQuery.
+//
+// FeatureID på det jordstykke, som jordstykket er udstykket fra. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Moderjordstykke(s ...string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "moderjordstykke", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Srid will add a parameter for 'srid' to the JordstykkeQuery.
+//
+// Angiver SRID for det koordinatsystem, som geospatiale parametre er angivet i. Default er 4326 (WGS84).
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Srid(s string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "srid", Values: []string{s}, Multi: false, Null: false})
+	return q
+}
+
+// Polygon will add a parameter for 'polygon' to the JordstykkeQuery.
+//
+// Find de jordstykker, som overlapper det angivne polygon.
+// Polygonet specificeres som et array af koordinater på samme måde som koordinaterne
+// specificeres i GeoJSON's polygon.
+// Bemærk at polygoner skal være lukkede, dvs. at første og sidste koordinat skal være identisk.
+// Eksempel: Polygon("[[[10.3,55.3],[10.4,55.3],[10.4,55.31],[10.4,55.31],[10.3,55.3]]]")
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Polygon(s string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "polygon", Values: []string{s}, Multi: false, Null: false})
+	return q
+}
+
+// Cirkel will add a parameter for 'cirkel' to the JordstykkeQuery.
+//
+// Find de jordstykker, som overlapper den cirkel angivet af koordinatet (x,y) og radius r.
+// Radius angives i meter. Cirkel("{x},{y},{r}")
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Cirkel(s string) *JordstykkeQuery {
+	q.add(&textQuery{Name: "cirkel", Values: []string{s}, Multi: false, Null: false})
+	return q
+}
+
+// Side will add a parameter for 'side' to the JordstykkeQuery.
+//
+// Angiver hvilken siden som skal leveres. Se Paginering.
+// http://dawa.aws.dk/generelt#paginering
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) Side(i int) *JordstykkeQuery {
+	q.add(&textQuery{Name: "side", Values: []string{strconv.Itoa(i)}, Multi: false, Null: true})
+	return q
+}
+
+// PerSide will add a parameter for 'per_side' to the JordstykkeQuery.
+//
+// Antal resultater per side. Se Paginering.
+// http://dawa.aws.dk/generelt#paginering
+//
+// See documentation at http://dawa.aws.dk/matrikelkortetdok
+func (q *JordstykkeQuery) PerSide(i int) *JordstykkeQuery {
+	q.add(&textQuery{Name: "per_side", Values: []string{strconv.Itoa(i)}, Multi: false, Null: true})
+	return q
+}
+
+// NoFormat will disable extra whitespace. Always enabled when querying
+func (q *JordstykkeQuery) NoFormat() *JordstykkeQuery {
+	q.add(&textQuery{Name: "noformat", Multi: false, Null: true})
+	return q
+}
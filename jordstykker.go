@@ -0,0 +1,136 @@
+package dawa
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/ugorji/go/codec"
+)
+
+// Et jordstykke er en matrikulær enhed, dvs. et areal som er registreret i matriklen.
+// Et jordstykke er identificeret ved ejerlavkoden og matrikelnummeret.
+//
+// Jordstykker er udstillet under /jordstykker
+type Jordstykke struct {
+	Href                 string       `json:"href"`                  // Jordstykkets unikke URL.
+	Ejerlav              Ejerlav      `json:"ejerlav"`               // Det matrikulære ejerlav som jordstykket ligger i.
+	Matrikelnr           string       `json:"matrikelnr"`            // Matrikelnummer. Unikt indenfor et ejerlav.
+	FeatureID            int          `json:"featureid"`             // Jordstykkets featureid i matriklen.
+	Kommune              KommuneRef   `json:"kommune"`               // Kommunen som jordstykket er beliggende i.
+	Region               RegionRef    `json:"region"`                // Regionen som jordstykket er beliggende i.
+	Sogn                 SognRef      `json:"sogn"`                  // Sognet som jordstykket er beliggende i.
+	Retskreds            RetskredsRef `json:"retskreds"`             // Retskredsen som jordstykket er beliggende i.
+	EsrEjendomsNr        string       `json:"esrejendomsnr"`         // ESR Ejendomsnummer. Indtil 7 cifre.
+	UdvidetEsrEjendomsNr string       `json:"udvidet_esrejendomsnr"` // ESR Ejendomsnummer med kommunekode foran. Indtil 10 cifre.
+	SfeEjendomsNr        string       `json:"sfeejendomsnr"`         // Nummer for det samlede faste ejendom (SFE), som jordstykket er en del af.
+	BFENummer            int          `json:"bfenummer"`             // BFE-nummer for det samlede faste ejendom, som jordstykket er en del af.
+	RegistreretAreal     int          `json:"registreretareal"`      // Jordstykkets registrerede areal i kvadratmeter.
+	Vejareal             int          `json:"vejareal"`              // Den del af jordstykkets areal, der er udlagt til vej, i kvadratmeter.
+	Fælleslod            bool         `json:"fælleslod"`             // Angiver om jordstykket er en fælleslod.
+	Moderjordstykke      int          `json:"moderjordstykke"`       // FeatureID på det jordstykke, som jordstykket er udstykket fra.
+	Bbox                 []float64    `json:"bbox"`                  // Jordstykkets bounding box som [minx, miny, maxx, maxy].
+	VisueltCenter        []float64    `json:"visueltcenter"`         // Et punkt inden for jordstykket, som egner sig til visning på kort, som [x,y].
+	Geometri             MultiPolygon `json:"geometri,omitempty"`    // Jordstykkets geometri. Kun udfyldt ved import fra GeoJSON.
+}
+
+// JordstykkeIter is an Iterator that enable you to get individual entries.
+type JordstykkeIter struct {
+	a   chan Jordstykke
+	err error
+	validating
+	closer
+}
+
+// Next will return the next item.
+// It will return an error if that has been encountered.
+// When there are not more entries nil, io.EOF will be returned.
+func (a *JordstykkeIter) Next() (*Jordstykke, error) {
+	for {
+		v, ok := <-a.a
+		if !ok {
+			return nil, a.err
+		}
+		if !a.skip(&v) {
+			return &v, nil
+		}
+	}
+}
+
+// ValidateTo will make the iterator validate each record with its Validate method.
+// Invalid records are sent to the sink and skipped, instead of aborting the import.
+// It must be called before the first call to Next.
+func (a *JordstykkeIter) ValidateTo(sink ErrorSink) *JordstykkeIter {
+	a.sink = sink
+	return a
+}
+
+// ImportJordstykkerJSON will import "jordstykker" from a JSON input, supplied to the reader.
+// An iterator will be returned that return all items.
+//
+// Note that the JSON format does not contain the geometry. Use ImportJordstykkerGeoJSON to get that.
+func ImportJordstykkerJSON(in io.Reader) (*JordstykkeIter, error) {
+	var h codec.JsonHandle
+	h.DecodeOptions.ErrorIfNoField = JSONStrictFieldCheck
+	// use a buffered reader for efficiency
+	if _, ok := in.(io.ByteScanner); !ok {
+		in = bufio.NewReader(in)
+	}
+	ret := &JordstykkeIter{a: make(chan Jordstykke, 100)}
+	go func() {
+		defer close(ret.a)
+		var dec *codec.Decoder = codec.NewDecoder(in, &h)
+		ret.err = dec.Decode(&ret.a)
+		if ret.err == nil {
+			ret.err = io.EOF
+		}
+	}()
+
+	return ret, nil
+}
+
+// ImportJordstykkerGeoJSON will import "jordstykker" from a GeoJSON FeatureCollection, supplied to the reader.
+// An iterator will be returned that return all items, including the geometry of each item.
+func ImportJordstykkerGeoJSON(in io.Reader) (*JordstykkeIter, error) {
+	ret := &JordstykkeIter{a: make(chan Jordstykke, 100)}
+	go func() {
+		defer close(ret.a)
+		ret.err = decodeGeoJSONFeatures(in, func(f geoJSONFeature) {
+			v := f.Properties
+			a := Jordstykke{}
+			a.Href = v.String("href")
+			a.Ejerlav.Kode = v.Int("ejerlavkode")
+			a.Ejerlav.Navn = v.String("ejerlavnavn")
+			a.Matrikelnr = v.String("matrikelnr")
+			a.FeatureID = v.Int("featureid")
+			a.Kommune.Kode = v.String("kommunekode")
+			a.Kommune.Navn = v.String("kommunenavn")
+			a.Region.Kode = v.String("regionskode")
+			a.Region.Navn = v.String("regionsnavn")
+			a.Sogn.Kode = v.String("sognekode")
+			a.Sogn.Navn = v.String("sognenavn")
+			a.Retskreds.Kode = v.String("retskredskode")
+			a.Retskreds.Navn = v.String("retskredsnavn")
+			a.EsrEjendomsNr = v.String("esrejendomsnr")
+			a.UdvidetEsrEjendomsNr = v.String("udvidet_esrejendomsnr")
+			a.SfeEjendomsNr = v.String("sfeejendomsnr")
+			a.BFENummer = v.Int("bfenummer")
+			a.RegistreretAreal = v.Int("registreretareal")
+			a.Vejareal = v.Int("vejareal")
+			a.Fælleslod = v.Bool("fælleslod")
+			a.Moderjordstykke = v.Int("moderjordstykke")
+			a.Geometri = f.Geometry
+			if len(f.Geometry) > 0 {
+				minX, minY, maxX, maxY := f.Geometry.Bounds()
+				a.Bbox = []float64{minX, minY, maxX, maxY}
+			}
+			if _, ok := v["visueltcenter_x"]; ok {
+				a.VisueltCenter = []float64{v.Float("visueltcenter_x"), v.Float("visueltcenter_y")}
+			}
+			ret.a <- a
+		})
+		if ret.err == nil {
+			ret.err = io.EOF
+		}
+	}()
+	return ret, nil
+}
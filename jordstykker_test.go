@@ -0,0 +1,220 @@
+package dawa
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var jordstykker_json_input = `
+[
+{
+  "href": "http://dawa.aws.dk/jordstykker/2000174/377",
+  "ejerlav": {
+    "kode": 2000174,
+    "navn": "Udenbys Vester Kvarter, København",
+    "href": "http://dawa.aws.dk/ejerlav/2000174"
+  },
+  "matrikelnr": "377",
+  "featureid": 2564577,
+  "kommune": {
+    "href": "http://dawa.aws.dk/kommuner/101",
+    "kode": "0101",
+    "navn": "København"
+  },
+  "region": {
+    "href": "http://dawa.aws.dk/regioner/1084",
+    "kode": "1084",
+    "navn": "Region Hovedstaden"
+  },
+  "sogn": {
+    "href": "http://dawa.aws.dk/sogne/9185",
+    "kode": "9185",
+    "navn": "Vesterbro"
+  },
+  "retskreds": {
+    "href": "http://dawa.aws.dk/retskredse/1101",
+    "kode": "1101",
+    "navn": "Københavns Byret"
+  },
+  "esrejendomsnr": "9343",
+  "udvidet_esrejendomsnr": "1019343",
+  "sfeejendomsnr": "6032497",
+  "bfenummer": 6032497,
+  "registreretareal": 402,
+  "vejareal": 0,
+  "fælleslod": false,
+  "moderjordstykke": 0,
+  "bbox": [12.5580, 55.6719, 12.5585, 55.6722],
+  "visueltcenter": [12.5582, 55.6720]
+}
+]
+`
+
+var jordstykker_geojson_input = `
+{
+  "type": "FeatureCollection",
+  "features": [
+    {
+      "type": "Feature",
+      "geometry": {
+        "type": "Polygon",
+        "coordinates": [[[12.5580, 55.6719], [12.5585, 55.6719], [12.5585, 55.6722], [12.5580, 55.6722], [12.5580, 55.6719]]]
+      },
+      "properties": {
+        "ejerlavkode": 2000174,
+        "ejerlavnavn": "Udenbys Vester Kvarter, København",
+        "matrikelnr": "377",
+        "featureid": 2564577,
+        "kommunekode": "0101",
+        "kommunenavn": "København",
+        "esrejendomsnr": "9343",
+        "bfenummer": 6032497,
+        "registreretareal": 402,
+        "fælleslod": false,
+        "visueltcenter_x": 12.5582,
+        "visueltcenter_y": 55.6720
+      }
+    }
+  ]
+}
+`
+
+func TestImportJordstykkerJSON(t *testing.T) {
+	var json_expect = []Jordstykke{
+		Jordstykke{
+			Href:                 "http://dawa.aws.dk/jordstykker/2000174/377",
+			Ejerlav:              Ejerlav{Href: "http://dawa.aws.dk/ejerlav/2000174", Kode: 2000174, Navn: "Udenbys Vester Kvarter, København"},
+			Matrikelnr:           "377",
+			FeatureID:            2564577,
+			Kommune:              KommuneRef{Href: "http://dawa.aws.dk/kommuner/101", Kode: "0101", Navn: "København"},
+			Region:               RegionRef{Href: "http://dawa.aws.dk/regioner/1084", Kode: "1084", Navn: "Region Hovedstaden"},
+			Sogn:                 SognRef{Href: "http://dawa.aws.dk/sogne/9185", Kode: "9185", Navn: "Vesterbro"},
+			Retskreds:            RetskredsRef{Href: "http://dawa.aws.dk/retskredse/1101", Kode: "1101", Navn: "Københavns Byret"},
+			EsrEjendomsNr:        "9343",
+			UdvidetEsrEjendomsNr: "1019343",
+			SfeEjendomsNr:        "6032497",
+			BFENummer:            6032497,
+			RegistreretAreal:     402,
+			Bbox:                 []float64{12.5580, 55.6719, 12.5585, 55.6722},
+			VisueltCenter:        []float64{12.5582, 55.6720},
+		},
+	}
+
+	b := bytes.NewBuffer([]byte(jordstykker_json_input))
+	iter, err := ImportJordstykkerJSON(b)
+	if err != nil {
+		t.Fatalf("ImportJordstykkerJSON: %v", err)
+	}
+	for _, expect := range json_expect {
+		item, err := iter.Next()
+		if err != nil {
+			t.Fatalf("ImportJordstykkerJSON, iter.Next(): %v", err)
+		}
+		if item == nil {
+			t.Fatalf("ImportJordstykkerJSON, iter.Next() returned nil value")
+		}
+		if !reflect.DeepEqual(*item, expect) {
+			t.Fatalf("ImportJordstykkerJSON, value mismatch.\nGot:\n%#v\nExpected:\n%#v\n", *item, expect)
+		}
+	}
+	// We should now have read all entries
+	_, err = iter.Next()
+	if err != io.EOF {
+		t.Fatalf("ImportJordstykkerJSON: Expected io.EOF, got:%v", err)
+	}
+}
+
+func TestImportJordstykkerGeoJSON(t *testing.T) {
+	var json_expect = []Jordstykke{
+		Jordstykke{
+			Ejerlav:          Ejerlav{Kode: 2000174, Navn: "Udenbys Vester Kvarter, København"},
+			Matrikelnr:       "377",
+			FeatureID:        2564577,
+			Kommune:          KommuneRef{Kode: "0101", Navn: "København"},
+			EsrEjendomsNr:    "9343",
+			BFENummer:        6032497,
+			RegistreretAreal: 402,
+			Bbox:             []float64{12.5580, 55.6719, 12.5585, 55.6722},
+			VisueltCenter:    []float64{12.5582, 55.6720},
+			Geometri: MultiPolygon{
+				[][][]float64{
+					[][]float64{{12.5580, 55.6719}, {12.5585, 55.6719}, {12.5585, 55.6722}, {12.5580, 55.6722}, {12.5580, 55.6719}},
+				},
+			},
+		},
+	}
+
+	iter, err := ImportJordstykkerGeoJSON(strings.NewReader(jordstykker_geojson_input))
+	if err != nil {
+		t.Fatalf("ImportJordstykkerGeoJSON: %v", err)
+	}
+	for _, expect := range json_expect {
+		item, err := iter.Next()
+		if err != nil {
+			t.Fatalf("ImportJordstykkerGeoJSON, iter.Next(): %v", err)
+		}
+		if !reflect.DeepEqual(*item, expect) {
+			t.Fatalf("ImportJordstykkerGeoJSON, value mismatch.\nGot:\n%#v\nExpected:\n%#v\n", *item, expect)
+		}
+	}
+	_, err = iter.Next()
+	if err != io.EOF {
+		t.Fatalf("ImportJordstykkerGeoJSON: Expected io.EOF, got:%v", err)
+	}
+}
+
+func TestJordstykkeQueryIterGeometri(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jordstykker" || r.URL.Query().Get("format") != "geojson" || r.URL.Query().Get("kommunekode") != "0101" {
+			http.Error(w, "unexpected request "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(jordstykker_geojson_input))
+	}))
+	defer ts.Close()
+
+	q := NewJordstykkeQuery().Kommunekode("0101")
+	q.host = ts.URL
+	before := q.URL()
+	for i := 0; i < 2; i++ {
+		iter, err := q.IterGeometri()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := iter.Next(); err != nil {
+			t.Fatal(err)
+		}
+		iter.Close()
+		// The query of the caller is not modified.
+		if q.URL() != before {
+			t.Fatalf("query was modified: %s, was %s", q.URL(), before)
+		}
+	}
+}
+
+var JordstykkeURL = []qb{
+	qb{NewJordstykkeQuery().URL(), DefaultHost + "/jordstykker"},
+	qb{NewJordstykkeComplete().URL(), DefaultHost + "/jordstykker/autocomplete"},
+
+	qb{NewJordstykkeQuery().Ejerlavkode(multiParam...).URL(), DefaultHost + "/jordstykker?ejerlavkode=" + multiEncoded + ""},
+	qb{NewJordstykkeQuery().Matrikelnr(multiParam...).URL(), DefaultHost + "/jordstykker?matrikelnr=" + multiEncoded + ""},
+	qb{NewJordstykkeQuery().Bfenummer(multiParam...).URL(), DefaultHost + "/jordstykker?bfenummer=" + multiEncoded + ""},
+	qb{NewJordstykkeQuery().Cirkel(singleParam).URL(), DefaultHost + "/jordstykker?cirkel=" + singleEncoded + ""},
+	qb{NewJordstykkeQuery().Q(singleParam).URL(), DefaultHost + "/jordstykker?q=" + singleEncoded + ""},
+	qb{NewJordstykkeQuery().PerSide(intParam).URL(), DefaultHost + "/jordstykker?per_side=" + intEncoded + ""},
+
+	qb{NewJordstykkeQuery().Ejerlavkode("2000174").Matrikelnr("377").URL(), DefaultHost + "/jordstykker?ejerlavkode=2000174&matrikelnr=377"},
+}
+
+func TestJordstykkeQueryURL(t *testing.T) {
+	for _, q := range JordstykkeURL {
+		if q.Got != q.Expected {
+			t.Fatalf("Unexpected value of parameter:\n     Was:\t%s\nExpected:\t%s", q.Got, q.Expected)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+package dawa
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Status is the status of an adresse or adgangsadresse, as received from BBR.
+//
+// The zero value means that the status is unknown.
+type Status int
+
+const (
+	StatusGældende  Status = 1 // Gældende (endelig) adresse.
+	StatusNedlagt   Status = 2 // Nedlagt adresse. Ikke med i DAWA, men kan optræde i hændelser.
+	StatusForeløbig Status = 3 // Foreløbig adresse.
+	StatusHenlagt   Status = 4 // Henlagt adresse. Ikke med i DAWA, men kan optræde i hændelser.
+)
+
+var statusNames = map[Status][2]string{
+	StatusGældende:  {"gældende", "Adressen er gældende."},
+	StatusNedlagt:   {"nedlagt", "Adressen er nedlagt."},
+	StatusForeløbig: {"foreløbig", "Adressen er foreløbig."},
+	StatusHenlagt:   {"henlagt", "Adressen er henlagt."},
+}
+
+// String returns the name of the status, for example "gældende".
+func (s Status) String() string {
+	if v, ok := statusNames[s]; ok {
+		return v[0]
+	}
+	return "Status(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Description returns a description of the status.
+func (s Status) Description() string {
+	if v, ok := statusNames[s]; ok {
+		return v[1]
+	}
+	return "Ukendt status."
+}
+
+// Valid returns true if the status is a known value.
+func (s Status) Valid() bool {
+	_, ok := statusNames[s]
+	return ok
+}
+
+// MarshalJSON encodes the status as a JSON number.
+func (s Status) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(s))), nil
+}
+
+// UnmarshalJSON decodes the status from a JSON number or string.
+// null is decoded as an unknown status. Unknown codes are kept, so Validate can report them.
+func (s *Status) UnmarshalJSON(b []byte) error {
+	return s.UnmarshalText(b)
+}
+
+// MarshalText encodes the status as its numeric value.
+func (s Status) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText decodes the status from its numeric value.
+// Values that are not numbers are decoded as -1.
+func (s *Status) UnmarshalText(b []byte) error {
+	*s = statusValue(string(b))
+	return nil
+}
+
+// Kilde is a code indicating the source of an adgangspunkt.
+//
+// The zero value means that the source is unknown.
+type Kilde int
+
+const (
+	KildeTekniskKort        Kilde = 1 // Oprettet maskinelt fra teknisk kort.
+	KildeMatrikel           Kilde = 2 // Oprettet maskinelt fra matrikelnummerets tyngdepunkt.
+	KildeKonsulent          Kilde = 3 // Eksternt indberettet af konsulent på vegne af kommunen.
+	KildeKortkontor         Kilde = 4 // Eksternt indberettet af kommunens kortkontor o.l.
+	KildeTekniskForvaltning Kilde = 5 // Oprettet af teknisk forvaltning.
+)
+
+var kildeNames = map[Kilde][2]string{
+	KildeTekniskKort:        {"teknisk kort", "Oprettet maskinelt fra teknisk kort."},
+	KildeMatrikel:           {"matrikel", "Oprettet maskinelt fra matrikelnummerets tyngdepunkt."},
+	KildeKonsulent:          {"konsulent", "Eksternt indberettet af konsulent på vegne af kommunen."},
+	KildeKortkontor:         {"kortkontor", "Eksternt indberettet af kommunens kortkontor o.l."},
+	KildeTekniskForvaltning: {"teknisk forvaltning", "Oprettet af teknisk forvaltning."},
+}
+
+// String returns a short name of the source, for example "teknisk kort".
+func (k Kilde) String() string {
+	if v, ok := kildeNames[k]; ok {
+		return v[0]
+	}
+	return "Kilde(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Description returns a description of the source.
+func (k Kilde) Description() string {
+	if v, ok := kildeNames[k]; ok {
+		return v[1]
+	}
+	return "Ukendt kilde."
+}
+
+// Valid returns true if the source is a known value.
+func (k Kilde) Valid() bool {
+	_, ok := kildeNames[k]
+	return ok
+}
+
+// MarshalJSON encodes the source as a JSON number.
+func (k Kilde) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(k))), nil
+}
+
+// UnmarshalJSON decodes the source from a JSON number or string.
+// null is decoded as an unknown source. Unknown codes are kept, so Validate can report them.
+func (k *Kilde) UnmarshalJSON(b []byte) error {
+	return k.UnmarshalText(b)
+}
+
+// MarshalText encodes the source as its numeric value.
+func (k Kilde) MarshalText() ([]byte, error) {
+	return k.MarshalJSON()
+}
+
+// UnmarshalText decodes the source from its numeric value.
+// Values that are not numbers are decoded as -1.
+func (k *Kilde) UnmarshalText(b []byte) error {
+	*k = kildeValue(string(b))
+	return nil
+}
+
+// Nøjagtighed is a code indicating the precision of an adgangspunkt.
+//
+// The empty value means that the precision is unknown.
+type Nøjagtighed string
+
+const (
+	NøjagtighedAbsolut  Nøjagtighed = "A" // Absolut placeret på et detaljeret grundkort, typisk bedre end +/- 2 meter.
+	NøjagtighedBeregnet Nøjagtighed = "B" // Beregnet, typisk midt på matrikelnummeret. Kan være ringere end +/- 100 meter.
+	NøjagtighedIngen    Nøjagtighed = "U" // Intet adressepunkt.
+)
+
+var nøjagtighedNames = map[Nøjagtighed][2]string{
+	NøjagtighedAbsolut:  {"absolut", "Adressepunktet er absolut placeret på et detaljeret grundkort, typisk med en nøjagtighed bedre end +/- 2 meter."},
+	NøjagtighedBeregnet: {"beregnet", "Adressepunktet er beregnet, typisk midt på matrikelnummeret. Nøjagtigheden kan være ringere end +/- 100 meter."},
+	NøjagtighedIngen:    {"ingen", "Der er intet adressepunkt."},
+}
+
+// String returns the code, for example "A".
+func (n Nøjagtighed) String() string {
+	return string(n)
+}
+
+// Description returns a description of the precision.
+func (n Nøjagtighed) Description() string {
+	if v, ok := nøjagtighedNames[n]; ok {
+		return v[1]
+	}
+	return "Ukendt nøjagtighed."
+}
+
+// Valid returns true if the precision is a known value.
+func (n Nøjagtighed) Valid() bool {
+	_, ok := nøjagtighedNames[n]
+	return ok
+}
+
+// Præcis returns true if the adgangspunkt is absolutely placed,
+// and not calculated or missing.
+func (n Nøjagtighed) Præcis() bool {
+	return n == NøjagtighedAbsolut
+}
+
+// MarshalText encodes the precision as its code.
+func (n Nøjagtighed) MarshalText() ([]byte, error) {
+	return []byte(n), nil
+}
+
+// UnmarshalText decodes the precision from its code.
+// An empty value is decoded as an unknown precision. Unknown codes are kept, so Validate can report them.
+func (n *Nøjagtighed) UnmarshalText(b []byte) error {
+	*n = nøjagtighedValue(string(b))
+	return nil
+}
+
+// Tekniskstandard is a code indicating the specification an adgangspunkt must fulfill.
+//
+// The empty value means that the specification is unknown.
+type Tekniskstandard string
+
+const (
+	TekniskstandardDør         Tekniskstandard = "TD" // 3 meter inde i bygningen ved det sted hvor indgangsdør e.l. skønnes placeret.
+	TekniskstandardTK          Tekniskstandard = "TK" // Udtrykkelig TK-standard: 3 meter inde i bygning, midt for længste side mod vej.
+	TekniskstandardNormal      Tekniskstandard = "TN" // Alm. teknisk standard: bygningstyngdepunkt eller blot i bygning.
+	TekniskstandardUspecificet Tekniskstandard = "UF" // Uspecificeret/foreløbig: ikke nødvendigvis placeret i bygning.
+)
+
+var tekniskstandardNames = map[Tekniskstandard][2]string{
+	TekniskstandardDør:         {"TD", "3 meter inde i bygningen ved det sted hvor indgangsdør e.l. skønnes placeret."},
+	TekniskstandardTK:          {"TK", "Udtrykkelig TK-standard: 3 meter inde i bygning, midt for længste side mod vej."},
+	TekniskstandardNormal:      {"TN", "Alm. teknisk standard: bygningstyngdepunkt eller blot i bygning."},
+	TekniskstandardUspecificet: {"UF", "Uspecificeret/foreløbig: ikke nødvendigvis placeret i bygning."},
+}
+
+// String returns the code, for example "TD".
+func (t Tekniskstandard) String() string {
+	return string(t)
+}
+
+// Description returns a description of the specification.
+func (t Tekniskstandard) Description() string {
+	if v, ok := tekniskstandardNames[t]; ok {
+		return v[1]
+	}
+	return "Ukendt teknisk standard."
+}
+
+// Valid returns true if the specification is a known value.
+func (t Tekniskstandard) Valid() bool {
+	_, ok := tekniskstandardNames[t]
+	return ok
+}
+
+// MarshalText encodes the specification as its code.
+func (t Tekniskstandard) MarshalText() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// UnmarshalText decodes the specification from its code.
+// An empty value is decoded as an unknown specification. Unknown codes are kept, so Validate can report them.
+func (t *Tekniskstandard) UnmarshalText(b []byte) error {
+	*t = tekniskstandardValue(string(b))
+	return nil
+}
+
+// Zone is the planning zone of an adgangsadresse, as defined in PlansystemDK.
+//
+// The empty value means that the zone is unknown.
+type Zone string
+
+const (
+	ZoneByzone          Zone = "Byzone"
+	ZoneSommerhusområde Zone = "Sommerhusområde"
+	ZoneLandzone        Zone = "Landzone"
+)
+
+var zoneNames = map[Zone]struct {
+	kode int
+	desc string
+}{
+	ZoneByzone:          {1, "Adressen ligger i byzone."},
+	ZoneSommerhusområde: {2, "Adressen ligger i sommerhusområde."},
+	ZoneLandzone:        {3, "Adressen ligger i landzone."},
+}
+
+// ZoneFromKode returns the zone with the specified zonekode,
+// as used by the 'zonekode' query parameter.
+// Mulige værdier er 1 for byzone, 2 for sommerhusområde og 3 for landzone.
+func ZoneFromKode(kode int) (Zone, error) {
+	for z, v := range zoneNames {
+		if v.kode == kode {
+			return z, nil
+		}
+	}
+	return "", fmt.Errorf("unknown zonekode %d", kode)
+}
+
+// String returns the name of the zone, for example "Byzone".
+func (z Zone) String() string {
+	return string(z)
+}
+
+// Description returns a description of the zone.
+func (z Zone) Description() string {
+	if v, ok := zoneNames[z]; ok {
+		return v.desc
+	}
+	return "Ukendt zone."
+}
+
+// Kode returns the zonekode of the zone. 0 is returned if the zone is unknown.
+func (z Zone) Kode() int {
+	return zoneNames[z].kode
+}
+
+// Valid returns true if the zone is a known value.
+func (z Zone) Valid() bool {
+	_, ok := zoneNames[z]
+	return ok
+}
+
+// MarshalText encodes the zone as its name.
+func (z Zone) MarshalText() ([]byte, error) {
+	return []byte(z), nil
+}
+
+// UnmarshalText decodes the zone from its name or zonekode.
+// An empty value is decoded as an unknown zone. Unknown values are kept, so Validate can report them.
+func (z *Zone) UnmarshalText(b []byte) error {
+	*z = zoneValue(string(b))
+	return nil
+}
+
+// unmarshalKode returns the numeric value of a JSON number, JSON string or text.
+// null and empty values return 0.
+func unmarshalKode(b []byte) (int, error) {
+	s := strings.Trim(string(b), "\" ")
+	if s == "" || s == "null" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+// statusValue returns the status with the numeric code s.
+// Unknown codes are kept, so Validate can report them.
+// Values that are not numbers are returned as -1.
+func statusValue(s string) Status {
+	i, err := unmarshalKode([]byte(s))
+	if err != nil {
+		return -1
+	}
+	return Status(i)
+}
+
+// kildeValue returns the kilde with the numeric code s.
+// Unknown codes are kept, so Validate can report them.
+// Values that are not numbers are returned as -1.
+func kildeValue(s string) Kilde {
+	i, err := unmarshalKode([]byte(s))
+	if err != nil {
+		return -1
+	}
+	return Kilde(i)
+}
+
+// nøjagtighedValue returns the nøjagtighed with the code s.
+// Unknown codes are kept, so Validate can report them.
+func nøjagtighedValue(s string) Nøjagtighed {
+	return Nøjagtighed(strings.ToUpper(s))
+}
+
+// tekniskstandardValue returns the tekniskstandard with the code s.
+// Unknown codes are kept, so Validate can report them.
+func tekniskstandardValue(s string) Tekniskstandard {
+	return Tekniskstandard(strings.ToUpper(s))
+}
+
+// zoneValue returns the zone with the name or zonekode s.
+// Unknown values are kept, so Validate can report them.
+func zoneValue(s string) Zone {
+	if i, err := strconv.Atoi(s); err == nil {
+		if z, err := ZoneFromKode(i); err == nil {
+			return z
+		}
+		return Zone(s)
+	}
+	for z := range zoneNames {
+		if strings.EqualFold(s, string(z)) {
+			return z
+		}
+	}
+	return Zone(s)
+}
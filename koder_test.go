@@ -0,0 +1,138 @@
+package dawa
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestKoderJSON(t *testing.T) {
+	var p Adgangspunkt
+	err := json.Unmarshal([]byte(`{"kilde":5,"nøjagtighed":"A","tekniskstandard":"TK"}`), &p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Kilde != KildeTekniskForvaltning || p.Nøjagtighed != NøjagtighedAbsolut || p.Tekniskstandard != TekniskstandardTK {
+		t.Fatalf("unexpected result: %#v", p)
+	}
+	if !p.Nøjagtighed.Præcis() || NøjagtighedBeregnet.Præcis() {
+		t.Fatal("unexpected Præcis() result")
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte(`"kilde":5,`)) || !bytes.Contains(b, []byte(`"nøjagtighed":"A"`)) || !bytes.Contains(b, []byte(`"tekniskstandard":"TK"`)) {
+		t.Fatalf("unexpected json: %s", string(b))
+	}
+
+	var a struct {
+		Status Status
+		Zone   Zone
+		Kilde  Kilde
+	}
+	err = json.Unmarshal([]byte(`{"Status":"3","Zone":"Sommerhusområde","Kilde":null}`), &a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Status != StatusForeløbig || a.Zone != ZoneSommerhusområde || a.Kilde != 0 {
+		t.Fatalf("unexpected result: %#v", a)
+	}
+
+	// Unknown values are kept.
+	err = json.Unmarshal([]byte(`{"Status":7,"Zone":"Byen","Kilde":"6"}`), &a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Status != 7 || a.Zone != "Byen" || a.Kilde != 6 || a.Status.Valid() || a.Zone.Valid() || a.Kilde.Valid() {
+		t.Fatalf("unexpected result: %#v", a)
+	}
+	err = json.Unmarshal([]byte(`{"Zone":"4","Status":"x"}`), &a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Zone != "4" || a.Status != -1 {
+		t.Fatalf("unexpected result: %#v", a)
+	}
+	err = json.Unmarshal([]byte(`{"nøjagtighed":"X","tekniskstandard":"xx"}`), &p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Nøjagtighed != "X" || p.Tekniskstandard != "XX" || p.Nøjagtighed.Valid() || p.Tekniskstandard.Valid() {
+		t.Fatalf("unexpected result: %#v", p)
+	}
+
+	// Importers validate values too.
+	iter, err := ImportAdgangsAdresserJSON(bytes.NewBufferString(`[{"id":"aa1","status":1,"zone":"Landzone","adgangspunkt":{"nøjagtighed":"B","kilde":2}}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	aa, err := iter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aa.Status != StatusGældende || aa.Zone != ZoneLandzone || aa.Adgangspunkt.Nøjagtighed != NøjagtighedBeregnet || aa.Adgangspunkt.Kilde != KildeMatrikel {
+		t.Fatalf("unexpected result: %#v", aa)
+	}
+
+	// Unknown codes are kept for Validate, like the CSV importers.
+	iter, err = ImportAdgangsAdresserJSON(bytes.NewBufferString(`[{"id":"aa1","status":7,"zone":"Ukendt","adgangspunkt":{"nøjagtighed":"Q","kilde":9}}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	aa, err = iter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aa.Status != 7 || aa.Zone != "Ukendt" || aa.Adgangspunkt.Nøjagtighed != "Q" || aa.Adgangspunkt.Kilde != 9 {
+		t.Fatalf("unexpected result: %#v", aa)
+	}
+	ve, ok := aa.Validate().(ValidationError)
+	if !ok {
+		t.Fatalf("expected ValidationError, got %v", aa.Validate())
+	}
+	want := map[string]bool{"Status": true, "Zone": true, "Adgangspunkt.Nøjagtighed": true, "Adgangspunkt.Kilde": true}
+	for _, f := range ve {
+		delete(want, f.Field)
+	}
+	if len(want) != 0 {
+		t.Fatalf("missing errors for %v in %v", want, ve)
+	}
+
+	aiter, err := ImportAdresserJSON(bytes.NewBufferString(`[{"id":"a1","status":7}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ad, err := aiter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ad.Status != 7 {
+		t.Fatalf("unexpected status: %v", ad.Status)
+	}
+}
+
+func TestKoderString(t *testing.T) {
+	if StatusGældende.String() != "gældende" || Status(9).String() != "Status(9)" || Status(9).Valid() {
+		t.Fatal("unexpected Status String()")
+	}
+	if KildeMatrikel.Description() != "Oprettet maskinelt fra matrikelnummerets tyngdepunkt." || Kilde(0).Description() != "Ukendt kilde." {
+		t.Fatal("unexpected Kilde Description()")
+	}
+	if NøjagtighedIngen.String() != "U" || TekniskstandardUspecificet.String() != "UF" || ZoneLandzone.String() != "Landzone" {
+		t.Fatal("unexpected String()")
+	}
+	if ZoneSommerhusområde.Kode() != 2 || Zone("").Kode() != 0 {
+		t.Fatal("unexpected Zone Kode()")
+	}
+	if z, err := ZoneFromKode(1); err != nil || z != ZoneByzone {
+		t.Fatalf("unexpected zone: %v, %v", z, err)
+	}
+	if _, err := ZoneFromKode(4); err == nil {
+		t.Fatal("expected error on unknown zonekode")
+	}
+	var z Zone
+	if err := z.UnmarshalText([]byte("3")); err != nil || z != ZoneLandzone {
+		t.Fatalf("unexpected zone: %v, %v", z, err)
+	}
+}
@@ -0,0 +1,215 @@
+package dawa
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// KVH contains the fields of a KVH-nøgle.
+// A KVH-nøgle is 12 tegn bestående af 4 cifre der repræsenterer kommunekode,
+// 4 cifre der repræsenterer vejkode efterfulgt af 4 tegn der repræsenter husnr.
+// The husnr is left padded with underscores, for example "05500001___6".
+type KVH struct {
+	Kommunekode int   // Kommunekode, 0-9999.
+	Vejkode     int   // Vejkode, 0-9999.
+	Husnr       Husnr // Husnummer.
+}
+
+// KVHX contains the fields of a KVHX-nøgle.
+// A KVHX-nøgle is 19 tegn bestående af en KVH-nøgle, 3 tegn der repræsenterer etage og 4 tegn der repræsenter dør.
+// Etage and dør are left padded with underscores, for example "05500001___6_______" or "01014459__12_st__tv".
+type KVHX struct {
+	KVH
+	Etage Etage // Etagebetegnelse. Empty if none.
+	Dør   Dør   // Dørbetegnelse. Empty if none.
+}
+
+// ParseKVH will parse a KVH-nøgle, for example "05500001___6".
+// Parts padded with zeros instead of underscores, like "010144590012", are also accepted.
+// An error is returned if the key doesn't have the correct length or contains invalid values.
+func ParseKVH(s string) (KVH, error) {
+	var k KVH
+	if utf8.RuneCountInString(s) != 12 {
+		return k, fmt.Errorf("invalid kvh '%s': length must be 12", s)
+	}
+	return parseKVH(s)
+}
+
+// ParseKVHX will parse a KVHX-nøgle, for example "05500001___6_______".
+// The values are normalized, so zero padded parts like "01014459__12_01__tv" are also accepted.
+// An error is returned if the key doesn't have the correct length or contains invalid values.
+func ParseKVHX(s string) (KVHX, error) {
+	var k KVHX
+	r := []rune(s)
+	if len(r) != 19 {
+		return k, fmt.Errorf("invalid kvhx '%s': length must be 19", s)
+	}
+	kvh, err := parseKVH(string(r[:12]))
+	if err != nil {
+		return k, err
+	}
+	k.KVH = kvh
+	if e := kvhUnpad(string(r[12:15])); e != "" {
+		k.Etage, err = ParseEtage(e)
+		if err != nil {
+			return k, fmt.Errorf("invalid kvhx '%s': invalid etage '%s'", s, e)
+		}
+	}
+	if d := kvhUnpad(string(r[15:])); d != "" {
+		k.Dør, err = ParseDør(d)
+		if err != nil {
+			return k, fmt.Errorf("invalid kvhx '%s': invalid dør '%s'", s, d)
+		}
+	}
+	return k, nil
+}
+
+func parseKVH(s string) (KVH, error) {
+	var k KVH
+	var err error
+	k.Kommunekode, err = kvhDigits(s[:4])
+	if err != nil {
+		return k, fmt.Errorf("invalid kvh '%s': invalid kommunekode '%s'", s, s[:4])
+	}
+	k.Vejkode, err = kvhDigits(s[4:8])
+	if err != nil {
+		return k, fmt.Errorf("invalid kvh '%s': invalid vejkode '%s'", s, s[4:8])
+	}
+	k.Husnr, err = ParseHusnr(kvhUnpad(s[8:]))
+	if err != nil {
+		return k, fmt.Errorf("invalid kvh '%s': invalid husnr '%s'", s, s[8:])
+	}
+	return k, nil
+}
+
+// FormatKVH returns the KVH-nøgle of the fields, for example "05500001___6".
+// An error is returned if a field cannot be represented in the key.
+func FormatKVH(k KVH) (string, error) {
+	if k.Kommunekode < 0 || k.Kommunekode > 9999 {
+		return "", fmt.Errorf("invalid kommunekode %d", k.Kommunekode)
+	}
+	if k.Vejkode < 0 || k.Vejkode > 9999 {
+		return "", fmt.Errorf("invalid vejkode %d", k.Vejkode)
+	}
+	if !k.Husnr.Valid() {
+		return "", fmt.Errorf("invalid husnr '%s'", k.Husnr)
+	}
+	return fmt.Sprintf("%04d%04d%s", k.Kommunekode, k.Vejkode, kvhPad(string(k.Husnr), 4)), nil
+}
+
+// FormatKVHX returns the KVHX-nøgle of the fields, for example "05500001___6_______".
+// An error is returned if a field cannot be represented in the key.
+func FormatKVHX(k KVHX) (string, error) {
+	kvh, err := FormatKVH(k.KVH)
+	if err != nil {
+		return "", err
+	}
+	if k.Etage != "" && !k.Etage.Valid() {
+		return "", fmt.Errorf("invalid etage '%s'", k.Etage)
+	}
+	if k.Dør != "" && !k.Dør.Valid() {
+		return "", fmt.Errorf("invalid dør '%s'", k.Dør)
+	}
+	return kvh + kvhPad(string(k.Etage), 3) + kvhPad(string(k.Dør), 4), nil
+}
+
+// String returns the KVH-nøgle. An empty string is returned if the fields are invalid.
+func (k KVH) String() string {
+	s, _ := FormatKVH(k)
+	return s
+}
+
+// String returns the KVHX-nøgle. An empty string is returned if the fields are invalid.
+func (k KVHX) String() string {
+	s, _ := FormatKVHX(k)
+	return s
+}
+
+// NewKVH returns the KVH fields of the adgangsadresse,
+// based on kommunekode, vejkode and husnr.
+// The Kvh field of the adgangsadresse is not used.
+func NewKVH(a *AdgangsAdresse) (KVH, error) {
+	var k KVH
+	var err error
+	k.Kommunekode, err = strconv.Atoi(a.Kommune.Kode)
+	if err != nil {
+		return k, fmt.Errorf("invalid kommunekode '%s'", a.Kommune.Kode)
+	}
+	k.Vejkode, err = strconv.Atoi(a.Vejstykke.Kode)
+	if err != nil {
+		return k, fmt.Errorf("invalid vejkode '%s'", a.Vejstykke.Kode)
+	}
+	k.Husnr = a.Husnr
+	return k, nil
+}
+
+// NewKVHX returns the KVHX fields of the adresse,
+// based on the adgangsadresse, etage and dør.
+// The Kvhx field of the adresse is not used.
+func NewKVHX(a *Adresse) (KVHX, error) {
+	kvh, err := NewKVH(&a.Adgangsadresse)
+	if err != nil {
+		return KVHX{}, err
+	}
+	return KVHX{KVH: kvh, Etage: a.Etage, Dør: a.Dør}, nil
+}
+
+// CheckKVH verifies that the Kvh field of the adgangsadresse matches kommunekode, vejkode and husnr.
+// An error describing the difference is returned if it doesn't.
+func (a AdgangsAdresse) CheckKVH() error {
+	k, err := NewKVH(&a)
+	if err != nil {
+		return err
+	}
+	want, err := FormatKVH(k)
+	if err != nil {
+		return err
+	}
+	if a.Kvh != want {
+		return fmt.Errorf("kvh '%s' does not match fields, expected '%s'", a.Kvh, want)
+	}
+	return nil
+}
+
+// CheckKVHX verifies that the Kvhx field of the adresse matches the adgangsadresse, etage and dør.
+// An error describing the difference is returned if it doesn't.
+func (a Adresse) CheckKVHX() error {
+	k, err := NewKVHX(&a)
+	if err != nil {
+		return err
+	}
+	want, err := FormatKVHX(k)
+	if err != nil {
+		return err
+	}
+	if a.Kvhx != want {
+		return fmt.Errorf("kvhx '%s' does not match fields, expected '%s'", a.Kvhx, want)
+	}
+	return nil
+}
+
+// kvhDigits returns the value of a fixed width number.
+func kvhDigits(s string) (int, error) {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid number '%s'", s)
+		}
+	}
+	return strconv.Atoi(s)
+}
+
+// kvhPad returns s left padded with underscores to n characters.
+func kvhPad(s string, n int) string {
+	l := utf8.RuneCountInString(s)
+	if l >= n {
+		return s
+	}
+	return strings.Repeat("_", n-l) + s
+}
+
+// kvhUnpad removes the left padding of a key part.
+func kvhUnpad(s string) string {
+	return strings.TrimLeft(s, "_")
+}
@@ -0,0 +1,80 @@
+package dawa
+
+import (
+	"testing"
+)
+
+func TestParseKVHX(t *testing.T) {
+	k, err := ParseKVHX("05500001___6_______")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.Kommunekode != 550 || k.Vejkode != 1 || k.Husnr != "6" || k.Etage != "" || k.Dør != "" {
+		t.Fatalf("unexpected result: %#v", k)
+	}
+	k, err = ParseKVHX("01014459_12B_st__tv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.Kommunekode != 101 || k.Vejkode != 4459 || k.Husnr != "12B" || k.Etage != "st" || k.Dør != "tv" {
+		t.Fatalf("unexpected result: %#v", k)
+	}
+	if s := k.String(); s != "01014459_12B_st__tv" {
+		t.Fatalf("unexpected key %q", s)
+	}
+	for _, in := range []string{"", "05500001___6", "0550000a___6_______", "05500001_____st__tv", "05500001___6_x1__tv", "05500001___6____a_b"} {
+		if _, err := ParseKVHX(in); err == nil {
+			t.Errorf("ParseKVHX(%q): expected error", in)
+		}
+	}
+	if s, err := FormatKVHX(KVHX{KVH: KVH{Kommunekode: 851, Vejkode: 5940, Husnr: "24"}, Etage: "kl2", Dør: "æ"}); err != nil || s != "08515940__24kl2___æ" {
+		t.Fatalf("unexpected key %q, %v", s, err)
+	}
+}
+
+func TestParseKVH(t *testing.T) {
+	k, err := ParseKVH("010144590012")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.Kommunekode != 101 || k.Vejkode != 4459 || k.Husnr != "12" {
+		t.Fatalf("unexpected result: %#v", k)
+	}
+	if k, err := ParseKVHX("01014459__12_01__tv"); err != nil || k.Etage != "1" || k.String() != "01014459__12__1__tv" {
+		t.Fatalf("unexpected result: %#v, %v", k, err)
+	}
+	if _, err := ParseKVH("05500001___6_______"); err == nil {
+		t.Fatal("expected error on kvhx")
+	}
+	if _, err := FormatKVH(KVH{Kommunekode: 10000, Vejkode: 1, Husnr: "1"}); err == nil {
+		t.Fatal("expected error on kommunekode")
+	}
+	if s := (KVH{Kommunekode: 550, Vejkode: 1, Husnr: "x"}).String(); s != "" {
+		t.Fatalf("expected empty key, got %q", s)
+	}
+}
+
+func TestCheckKVHX(t *testing.T) {
+	var a Adresse
+	a.Adgangsadresse.Kommune.Kode = "0550"
+	a.Adgangsadresse.Vejstykke.Kode = "0001"
+	a.Adgangsadresse.Husnr = "6"
+	a.Adgangsadresse.Kvh = "05500001___6"
+	a.Etage = "2"
+	a.Dør = "th"
+	a.Kvhx = "05500001___6__2__th"
+	if err := a.Adgangsadresse.CheckKVH(); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.CheckKVHX(); err != nil {
+		t.Fatal(err)
+	}
+	a.Dør = "tv"
+	if err := a.CheckKVHX(); err == nil {
+		t.Fatal("expected mismatch")
+	}
+	a.Adgangsadresse.Kvh = ""
+	if err := a.Adgangsadresse.CheckKVH(); err == nil {
+		t.Fatal("expected mismatch on missing key")
+	}
+}
@@ -24,7 +24,8 @@ type ListQuery struct {
 
 // ListQuery returns query item for searching DAWA for specific list types.
 //
-// Supported list types are "regioner","sogne","retskredse","politikredse","opstillingskredse","valglandsdele","ejerlav", "adgangsadresser", "adresser" or "postnumre".
+// Supported list types are "regioner","sogne","retskredse","politikredse","opstillingskredse","valglandsdele","ejerlav", "adgangsadresser", "adresser", "postnumre", "jordstykker" or "stednavne".
+// Election and statistical areas are available as "storkredse", "landsdele", "afstemningsomraader" and "menighedsraadsafstemningsomraader".
 // Use the corresponding iterator function, for instance i.NextRegion() to get typed results.
 //
 // See 'examples/query-list.go' for a usage example.
@@ -77,6 +78,7 @@ type ListIter struct {
 	a     reflect.Value // Channel
 	eType reflect.Type  // Type of the element
 	err   error
+	validating
 }
 
 func makeChannel(t reflect.Type, chanDir reflect.ChanDir, buffer int) reflect.Value {
@@ -147,6 +149,18 @@ func (q ListQuery) Type() interface{} {
 		return &Adresse{}
 	case "postnumre":
 		return &Postnummer{}
+	case "jordstykker":
+		return &Jordstykke{}
+	case "stednavne":
+		return &Stednavn{}
+	case "storkredse":
+		return &Storkreds{}
+	case "landsdele":
+		return &Landsdel{}
+	case "afstemningsomraader":
+		return &Afstemningsområde{}
+	case "menighedsraadsafstemningsomraader":
+		return &Menighedsrådsafstemningsområde{}
 	}
 	return nil
 }
@@ -155,11 +169,24 @@ func (q ListQuery) Type() interface{} {
 // It will return an error if that has been encountered.
 // When there are not more entries nil, io.EOF will be returned.
 func (a *ListIter) Next() (interface{}, error) {
-	v, ok := a.a.Recv()
-	if ok {
-		return v.Interface(), nil
+	for {
+		v, ok := a.a.Recv()
+		if !ok {
+			return nil, a.err
+		}
+		item := v.Interface()
+		if !a.skip(item) {
+			return item, nil
+		}
 	}
-	return nil, a.err
+}
+
+// ValidateTo will make the iterator validate each item with its Validate method.
+// Invalid items are sent to the sink and skipped, instead of aborting the import.
+// It must be called before the first call to Next.
+func (a *ListIter) ValidateTo(sink ErrorSink) *ListIter {
+	a.sink = sink
+	return a
 }
 
 // NextKommune will return the next item.
@@ -305,6 +332,84 @@ func (a *ListIter) NextPostnummer() (*Postnummer, error) {
 	return item.(*Postnummer), nil
 }
 
+// NextJordstykke will return the next item.
+// The query must be built using the corresponding type. See NewListQuery() function.
+func (a *ListIter) NextJordstykke() (*Jordstykke, error) {
+	if !a.eType.ConvertibleTo(reflect.TypeOf(&Jordstykke{})) {
+		return nil, fmt.Errorf("Wrong type requested from iterator. Expected %s", a.eType.String())
+	}
+	item, err := a.Next()
+	if err != nil {
+		return nil, a.err
+	}
+	return item.(*Jordstykke), nil
+}
+
+// NextStednavn will return the next item.
+// The query must be built using the corresponding type. See NewListQuery() function.
+func (a *ListIter) NextStednavn() (*Stednavn, error) {
+	if !a.eType.ConvertibleTo(reflect.TypeOf(&Stednavn{})) {
+		return nil, fmt.Errorf("Wrong type requested from iterator. Expected %s", a.eType.String())
+	}
+	item, err := a.Next()
+	if err != nil {
+		return nil, a.err
+	}
+	return item.(*Stednavn), nil
+}
+
+// NextStorkreds will return the next item.
+// The query must be built using the corresponding type. See NewListQuery() function.
+func (a *ListIter) NextStorkreds() (*Storkreds, error) {
+	if !a.eType.ConvertibleTo(reflect.TypeOf(&Storkreds{})) {
+		return nil, fmt.Errorf("Wrong type requested from iterator. Expected %s", a.eType.String())
+	}
+	item, err := a.Next()
+	if err != nil {
+		return nil, a.err
+	}
+	return item.(*Storkreds), nil
+}
+
+// NextLandsdel will return the next item.
+// The query must be built using the corresponding type. See NewListQuery() function.
+func (a *ListIter) NextLandsdel() (*Landsdel, error) {
+	if !a.eType.ConvertibleTo(reflect.TypeOf(&Landsdel{})) {
+		return nil, fmt.Errorf("Wrong type requested from iterator. Expected %s", a.eType.String())
+	}
+	item, err := a.Next()
+	if err != nil {
+		return nil, a.err
+	}
+	return item.(*Landsdel), nil
+}
+
+// NextAfstemningsområde will return the next item.
+// The query must be built using the corresponding type. See NewListQuery() function.
+func (a *ListIter) NextAfstemningsområde() (*Afstemningsområde, error) {
+	if !a.eType.ConvertibleTo(reflect.TypeOf(&Afstemningsområde{})) {
+		return nil, fmt.Errorf("Wrong type requested from iterator. Expected %s", a.eType.String())
+	}
+	item, err := a.Next()
+	if err != nil {
+		return nil, a.err
+	}
+	return item.(*Afstemningsområde), nil
+}
+
+// NextMenighedsrådsafstemningsområde will return the next item.
+// The query must be built using the corresponding type. See NewListQuery() function.
+func (a *ListIter) NextMenighedsrådsafstemningsområde() (*Menighedsrådsafstemningsområde, error) {
+	if !a.eType.ConvertibleTo(reflect.TypeOf(&Menighedsrådsafstemningsområde{})) {
+		return nil, fmt.Errorf("Wrong type requested from iterator. Expected %s", a.eType.String())
+	}
+	item, err := a.Next()
+	if err != nil {
+		return nil, a.err
+	}
+	return item.(*Menighedsrådsafstemningsområde), nil
+}
+
 // NewReverseQuery will create a reverse location to item lookup. Parameters are:
 //
 //	* listType: See NewListQuery() for valid options.
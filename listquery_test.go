@@ -73,7 +73,7 @@ func TestListQueryURL(t *testing.T) {
 
 }
 
-var listTypes = []string{"regioner", "sogne", "retskredse", "politikredse", "opstillingskredse", "valglandsdele", "ejerlav", "adgangsadresser", "adresser", "postnumre"}
+var listTypes = []string{"regioner", "sogne", "retskredse", "politikredse", "opstillingskredse", "valglandsdele", "ejerlav", "adgangsadresser", "adresser", "postnumre", "jordstykker", "stednavne", "storkredse", "landsdele", "afstemningsomraader", "menighedsraadsafstemningsomraader"}
 
 func TestListQueryTypes(t *testing.T) {
 	for _, name := range listTypes {
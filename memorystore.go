@@ -0,0 +1,152 @@
+package dawa
+
+import (
+	"sync"
+)
+
+// MemoryStore is a Store that keeps all data in memory.
+// It is safe for concurrent use.
+//
+// Use NewMemoryStore() to get an initialized object.
+type MemoryStore struct {
+	mu              sync.RWMutex
+	seq             int64
+	adresser        map[string]ReplikeringAdresse
+	adgangsadresser map[string]ReplikeringAdgangsAdresse
+	vejstykker      map[[2]int]ReplikeringVejstykke
+	postnumre       map[int]ReplikeringPostnummer
+}
+
+// NewMemoryStore returns a new, empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		adresser:        make(map[string]ReplikeringAdresse),
+		adgangsadresser: make(map[string]ReplikeringAdgangsAdresse),
+		vejstykker:      make(map[[2]int]ReplikeringVejstykke),
+		postnumre:       make(map[int]ReplikeringPostnummer),
+	}
+}
+
+// Sekvensnummer returns the sekvensnummer of the last committed transaction.
+func (m *MemoryStore) Sekvensnummer() (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.seq, nil
+}
+
+// Begin starts a new transaction.
+// Changes are buffered until Commit is called.
+func (m *MemoryStore) Begin() (StoreTx, error) {
+	return &memoryTx{m: m}, nil
+}
+
+// Adresse returns the adresse with the specified id.
+func (m *MemoryStore) Adresse(id string) (ReplikeringAdresse, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.adresser[id]
+	return v, ok
+}
+
+// AdgangsAdresse returns the adgangsadresse with the specified id.
+func (m *MemoryStore) AdgangsAdresse(id string) (ReplikeringAdgangsAdresse, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.adgangsadresser[id]
+	return v, ok
+}
+
+// Vejstykke returns the vejstykke with the specified kommunekode and vejkode.
+func (m *MemoryStore) Vejstykke(kommunekode, kode int) (ReplikeringVejstykke, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.vejstykker[[2]int{kommunekode, kode}]
+	return v, ok
+}
+
+// Postnummer returns the postnummer with the specified number.
+func (m *MemoryStore) Postnummer(nr int) (ReplikeringPostnummer, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.postnumre[nr]
+	return v, ok
+}
+
+// Len returns the number of stored objects of the entity type.
+func (m *MemoryStore) Len(e Entity) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	switch e {
+	case EntityAdresse:
+		return len(m.adresser)
+	case EntityAdgangsAdresse:
+		return len(m.adgangsadresser)
+	case EntityVejstykke:
+		return len(m.vejstykker)
+	case EntityPostnummer:
+		return len(m.postnumre)
+	}
+	return 0
+}
+
+// memoryTx buffers changes to a MemoryStore until committed.
+type memoryTx struct {
+	m   *MemoryStore
+	ops []func(m *MemoryStore)
+}
+
+func (t *memoryTx) UpsertAdresse(a ReplikeringAdresse) error {
+	t.ops = append(t.ops, func(m *MemoryStore) { m.adresser[a.ID] = a })
+	return nil
+}
+
+func (t *memoryTx) DeleteAdresse(a ReplikeringAdresse) error {
+	t.ops = append(t.ops, func(m *MemoryStore) { delete(m.adresser, a.ID) })
+	return nil
+}
+
+func (t *memoryTx) UpsertAdgangsAdresse(a ReplikeringAdgangsAdresse) error {
+	t.ops = append(t.ops, func(m *MemoryStore) { m.adgangsadresser[a.ID] = a })
+	return nil
+}
+
+func (t *memoryTx) DeleteAdgangsAdresse(a ReplikeringAdgangsAdresse) error {
+	t.ops = append(t.ops, func(m *MemoryStore) { delete(m.adgangsadresser, a.ID) })
+	return nil
+}
+
+func (t *memoryTx) UpsertVejstykke(v ReplikeringVejstykke) error {
+	t.ops = append(t.ops, func(m *MemoryStore) { m.vejstykker[[2]int{v.Kommunekode, v.Kode}] = v })
+	return nil
+}
+
+func (t *memoryTx) DeleteVejstykke(v ReplikeringVejstykke) error {
+	t.ops = append(t.ops, func(m *MemoryStore) { delete(m.vejstykker, [2]int{v.Kommunekode, v.Kode}) })
+	return nil
+}
+
+func (t *memoryTx) UpsertPostnummer(p ReplikeringPostnummer) error {
+	t.ops = append(t.ops, func(m *MemoryStore) { m.postnumre[p.Nr] = p })
+	return nil
+}
+
+func (t *memoryTx) DeletePostnummer(p ReplikeringPostnummer) error {
+	t.ops = append(t.ops, func(m *MemoryStore) { delete(m.postnumre, p.Nr) })
+	return nil
+}
+
+func (t *memoryTx) Commit(seq int64) error {
+	t.m.mu.Lock()
+	defer t.m.mu.Unlock()
+	for _, op := range t.ops {
+		op(t.m)
+	}
+	t.m.seq = seq
+	t.ops = nil
+	return nil
+}
+
+func (t *memoryTx) Rollback() error {
+	t.ops = nil
+	return nil
+}
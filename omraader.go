@@ -0,0 +1,208 @@
+package dawa
+
+import (
+	"io"
+	"math"
+	"sort"
+)
+
+// Område is an administrative area with its geometry, loaded into an OmrådeIndex.
+type Område struct {
+	Type     string       // The list type of the area, for instance "regioner" or "sogne". See NewListQuery().
+	Kode     string       // Identifikation af området. For postnumre the 'nr' is used.
+	Navn     string       // Områdets navn.
+	Geometri MultiPolygon // Områdets geometri i ETRS89/UTM32.
+
+	minX, minY, maxX, maxY float64
+}
+
+// OmrådeIndex is an in-memory index of administrative areas,
+// that allows looking up the areas of any coordinate without contacting the server.
+//
+// This allows points that are not addresses, like the position of an incident report,
+// to be assigned the same region, sogn, politikreds, retskreds, opstillingskreds and zone,
+// as DAWA computes for adgangsadresser.
+//
+// Areas are loaded from GeoJSON downloads of the ListQuery types, like "regioner", "kommuner",
+// "sogne", "politikredse", "retskredse", "opstillingskredse" and "postnumre".
+// Zones can be loaded with the type "zoner" from GeoJSON with a 'zone' property.
+// Geometries may be in WGS84 or ETRS89/UTM32 (srid=25832), and are stored as ETRS89/UTM32.
+//
+// Coordinates of lookups may be given in the same formats as for SpatialIndex.
+//
+// The index is not safe for concurrent modification, but
+// lookups can be done concurrently when it is no longer modified.
+//
+// Use NewOmrådeIndex() to get an initialized object.
+// Example:
+//			ix := dawa.NewOmrådeIndex()
+//			err := ix.LoadListQuery(dawa.NewListQuery("sogne", false))
+//			err = ix.LoadGeoJSON("regioner", file)
+//			områder := ix.Lookup([]float64{12.5582, 55.6720})
+type OmrådeIndex struct {
+	cellSize float64
+	cells    map[spatialCell][]int
+	områder  []Område
+}
+
+// NewOmrådeIndex returns a new, empty OmrådeIndex.
+func NewOmrådeIndex() *OmrådeIndex {
+	return &OmrådeIndex{cellSize: 10000, cells: make(map[spatialCell][]int)}
+}
+
+// LoadListQuery will request the query as GeoJSON and add all areas to the index.
+// The type of the areas is the list type of the query.
+// The query is not modified.
+func (ix *OmrådeIndex) LoadListQuery(q *ListQuery) error {
+	c := *q
+	c.query = q.query.clone()
+	c.Add("format", "geojson")
+	resp, err := c.NoFormat().Request()
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+	return ix.LoadGeoJSON(q.listType, resp)
+}
+
+// LoadGeoJSON will add all areas of a GeoJSON FeatureCollection, supplied to the reader.
+// The listType is the type of the areas, for instance "sogne". See NewListQuery().
+//
+// The code of each area is read from the 'kode' property, or 'nr' for postnumre.
+// The name is read from the 'navn' property, or 'zone' for zoner.
+// Features without a geometry are skipped.
+func (ix *OmrådeIndex) LoadGeoJSON(listType string, in io.Reader) error {
+	return decodeGeoJSONFeatures(in, func(f geoJSONFeature) {
+		v := f.Properties
+		o := Område{Type: listType, Kode: v.String("kode"), Navn: v.String("navn"), Geometri: f.Geometry}
+		if o.Kode == "" {
+			o.Kode = v.String("nr")
+		}
+		if o.Navn == "" {
+			o.Navn = v.String("zone")
+		}
+		ix.Add(o)
+	})
+}
+
+// Add will add an area to the index.
+// If the geometry is in WGS84, it is converted to ETRS89/UTM32.
+// False is returned if the area has no geometry.
+func (ix *OmrådeIndex) Add(o Område) bool {
+	if len(o.Geometri) == 0 {
+		return false
+	}
+	if minX, _, _, _ := o.Geometri.Bounds(); math.Abs(minX) < 1000 {
+		o.Geometri = o.Geometri.Transform(WGS84ToUTM32)
+	}
+	o.minX, o.minY, o.maxX, o.maxY = o.Geometri.Bounds()
+	n := len(ix.områder)
+	ix.områder = append(ix.områder, o)
+
+	c0, c1 := ix.cell(o.minX, o.minY), ix.cell(o.maxX, o.maxY)
+	for x := c0.x; x <= c1.x; x++ {
+		for y := c0.y; y <= c1.y; y++ {
+			c := spatialCell{x: x, y: y}
+			ix.cells[c] = append(ix.cells[c], n)
+		}
+	}
+	return true
+}
+
+// Len returns the number of areas in the index.
+func (ix *OmrådeIndex) Len() int {
+	return len(ix.områder)
+}
+
+// Lookup returns all areas containing the point, ordered by type and code.
+// The returned areas must not be modified.
+func (ix *OmrådeIndex) Lookup(point []float64) []*Område {
+	x, y, ok := spatialPoint(point)
+	if !ok {
+		return nil
+	}
+	var res []*Område
+	for _, n := range ix.cells[ix.cell(x, y)] {
+		o := &ix.områder[n]
+		if x < o.minX || x > o.maxX || y < o.minY || y > o.maxY {
+			continue
+		}
+		if o.Geometri.Contains(x, y) {
+			res = append(res, o)
+		}
+	}
+	sort.Sort(områdeByType(res))
+	return res
+}
+
+// LookupType returns the area of the given type containing the point.
+// If no area of the type contains the point, nil is returned.
+func (ix *OmrådeIndex) LookupType(point []float64, listType string) *Område {
+	for _, o := range ix.Lookup(point) {
+		if o.Type == listType {
+			return o
+		}
+	}
+	return nil
+}
+
+// Område returns the area with the specified type and code.
+// If there is no such area in the index, nil is returned.
+func (ix *OmrådeIndex) Område(listType, kode string) *Område {
+	for i := range ix.områder {
+		if o := &ix.områder[i]; o.Type == listType && o.Kode == kode {
+			return o
+		}
+	}
+	return nil
+}
+
+// Assign will set the areas of the adgangsadresse, based on its adgangspunkt.
+// Kommune, Postnummer, Region, Sogn, Politikreds, Retskreds, Opstillingskreds and Zone
+// are set for each type of area found. Other fields, including Href, are not modified.
+//
+// To assign areas to a point that is not an address, use an empty AdgangsAdresse:
+//			var a dawa.AdgangsAdresse
+//			a.Adgangspunkt.Koordinater = []float64{12.5582, 55.6720}
+//			ok := ix.Assign(&a)
+//
+// False is returned if no area contains the point.
+func (ix *OmrådeIndex) Assign(a *AdgangsAdresse) bool {
+	found := ix.Lookup(a.Adgangspunkt.Koordinater)
+	for _, o := range found {
+		switch o.Type {
+		case "kommuner":
+			a.Kommune.Kode, a.Kommune.Navn = o.Kode, o.Navn
+		case "postnumre":
+			a.Postnummer.Nr, a.Postnummer.Navn = o.Kode, o.Navn
+		case "regioner":
+			a.Region.Kode, a.Region.Navn = o.Kode, o.Navn
+		case "sogne":
+			a.Sogn.Kode, a.Sogn.Navn = o.Kode, o.Navn
+		case "politikredse":
+			a.Politikreds.Kode, a.Politikreds.Navn = o.Kode, o.Navn
+		case "retskredse":
+			a.Retskreds.Kode, a.Retskreds.Navn = o.Kode, o.Navn
+		case "opstillingskredse":
+			a.Opstillingskreds.Kode, a.Opstillingskreds.Navn = o.Kode, o.Navn
+		case "zoner":
+			a.Zone = Zone(o.Navn)
+		}
+	}
+	return len(found) > 0
+}
+
+func (ix *OmrådeIndex) cell(x, y float64) spatialCell {
+	return spatialCell{x: int32(math.Floor(x / ix.cellSize)), y: int32(math.Floor(y / ix.cellSize))}
+}
+
+type områdeByType []*Område
+
+func (o områdeByType) Len() int      { return len(o) }
+func (o områdeByType) Swap(i, j int) { o[i], o[j] = o[j], o[i] }
+func (o områdeByType) Less(i, j int) bool {
+	if o[i].Type != o[j].Type {
+		return o[i].Type < o[j].Type
+	}
+	return o[i].Kode < o[j].Kode
+}
@@ -0,0 +1,103 @@
+package dawa
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var omraader_regioner_geojson = `{"type":"FeatureCollection","features":[
+{"type":"Feature","properties":{"kode":"1084","navn":"Region Hovedstaden"},"geometry":{"type":"Polygon","coordinates":[[[12.0,55.5],[13.0,55.5],[13.0,56.0],[12.0,56.0],[12.0,55.5]]]}},
+{"type":"Feature","properties":{"kode":1085,"navn":"Region Sjælland"},"geometry":{"type":"Polygon","coordinates":[[[11.0,55.0],[12.0,55.0],[12.0,55.5],[11.0,55.5],[11.0,55.0]]]}},
+{"type":"Feature","properties":{"kode":"9999","navn":"Uden geometri"},"geometry":null}
+]}`
+
+// A square sogn around (12.5582, 55.6720) in ETRS89/UTM32 with a hole in the north-east corner.
+var omraader_sogne_geojson = `{"type":"FeatureCollection","features":[
+{"type":"Feature","properties":{"kode":"7038","navn":"Brønshøj"},"geometry":{"type":"MultiPolygon","coordinates":[[
+[[720000,6170000],[730000,6170000],[730000,6180000],[720000,6180000],[720000,6170000]],
+[[728000,6178000],[729000,6178000],[729000,6179000],[728000,6179000],[728000,6178000]]
+]]}}
+]}`
+
+func TestOmrådeIndex(t *testing.T) {
+	ix := NewOmrådeIndex()
+	err := ix.LoadGeoJSON("regioner", strings.NewReader(omraader_regioner_geojson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ix.LoadGeoJSON("sogne", strings.NewReader(omraader_sogne_geojson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ix.Add(Område{Type: "zoner", Navn: "Byzone", Geometri: MultiPolygon{{{{12.5, 55.6}, {12.6, 55.6}, {12.6, 55.7}, {12.5, 55.7}, {12.5, 55.6}}}}})
+	if ix.Len() != 4 {
+		t.Fatalf("expected 4 areas, got %d", ix.Len())
+	}
+
+	x, y := WGS84ToUTM32(12.5582, 55.6720)
+	points := [][]float64{{12.5582, 55.6720}, {55.6720, 12.5582}, {x, y}}
+	for _, p := range points {
+		res := ix.Lookup(p)
+		if len(res) != 3 || res[0].Type != "regioner" || res[1].Type != "sogne" || res[2].Type != "zoner" {
+			t.Fatalf("unexpected result for %v: %#v", p, res)
+		}
+		if res[0].Kode != "1084" || res[1].Navn != "Brønshøj" || res[2].Navn != "Byzone" {
+			t.Fatalf("unexpected result for %v: %#v", p, res)
+		}
+	}
+
+	if o := ix.LookupType([]float64{11.5, 55.2}, "regioner"); o == nil || o.Kode != "1085" || o.Navn != "Region Sjælland" {
+		t.Fatalf("unexpected result: %#v", o)
+	}
+	if o := ix.LookupType([]float64{11.5, 55.2}, "sogne"); o != nil {
+		t.Fatalf("expected no sogn, got %#v", o)
+	}
+	// Inside the hole of the sogn.
+	if o := ix.LookupType([]float64{728500, 6178500}, "sogne"); o != nil {
+		t.Fatalf("expected no sogn in hole, got %#v", o)
+	}
+	if res := ix.Lookup([]float64{10, 57}); len(res) != 0 {
+		t.Fatalf("expected no areas, got %#v", res)
+	}
+
+	var a AdgangsAdresse
+	a.Adgangspunkt.Koordinater = []float64{12.5582, 55.6720}
+	if !ix.Assign(&a) {
+		t.Fatal("expected areas to be assigned")
+	}
+	if a.Region.Kode != "1084" || a.Region.Navn != "Region Hovedstaden" || a.Sogn.Kode != "7038" || a.Zone != "Byzone" {
+		t.Fatalf("unexpected areas: %#v", a)
+	}
+	a.Adgangspunkt.Koordinater = nil
+	if ix.Assign(&a) {
+		t.Fatal("expected no areas without coordinates")
+	}
+}
+
+func TestOmrådeIndexLoadListQuery(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/regioner" || r.URL.Query().Get("format") != "geojson" || r.URL.Query().Get("kode") != "1084" {
+			http.Error(w, "unexpected request "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(omraader_regioner_geojson))
+	}))
+	defer ts.Close()
+
+	q := NewListQuery("regioner", false).Kode("1084")
+	q.host = ts.URL
+	before := q.URL()
+	ix := NewOmrådeIndex()
+	if err := ix.LoadListQuery(q); err != nil {
+		t.Fatal(err)
+	}
+	if ix.Len() != 2 {
+		t.Fatalf("expected 2 areas, got %d", ix.Len())
+	}
+	// The query of the caller is not modified.
+	if q.URL() != before {
+		t.Fatalf("query was modified: %s, was %s", q.URL(), before)
+	}
+}
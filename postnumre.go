@@ -19,6 +19,7 @@ type Postnummer struct {
 type PostnummerIter struct {
 	a   chan Postnummer
 	err error
+	validating
 	closer
 }
 
@@ -26,11 +27,23 @@ type PostnummerIter struct {
 // It will return an error if that has been encountered.
 // When there are not more entries nil, io.EOF will be returned.
 func (a *PostnummerIter) Next() (*Postnummer, error) {
-	v, ok := <-a.a
-	if ok {
-		return &v, nil
+	for {
+		v, ok := <-a.a
+		if !ok {
+			return nil, a.err
+		}
+		if !a.skip(&v) {
+			return &v, nil
+		}
 	}
-	return nil, a.err
+}
+
+// ValidateTo will make the iterator validate each record with its Validate method.
+// Invalid records are sent to the sink and skipped, instead of aborting the import.
+// It must be called before the first call to Next.
+func (a *PostnummerIter) ValidateTo(sink ErrorSink) *PostnummerIter {
+	a.sink = sink
+	return a
 }
 
 // ImportPostnumreJSON will import "postnumre" from a JSON input, supplied to the reader.
@@ -1,6 +1,7 @@
 package dawa
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/kpawlik/geojson"
@@ -40,6 +41,19 @@ func (q *query) Add(key, value string) {
 	q.add(&textQuery{Name: key, Values: []string{value}, Multi: false, Null: true})
 }
 
+// clone returns a copy of the query, so new keys can be added
+// without changing the original.
+func (q query) clone() query {
+	c := q
+	c.params = make(map[string]parameter, len(q.params))
+	for k, v := range q.params {
+		c.params[k] = v
+	}
+	c.keys = append([]string(nil), q.keys...)
+	c.warnings = append([]error(nil), q.warnings...)
+	return c
+}
+
 // This will  return any warnings that may have been generated while building the query.
 func (q query) Warnings() []error {
 	return q.warnings
@@ -170,8 +184,18 @@ func (r RequestError) Error() string {
 // this is returned.
 // In some cases the error will be a RequestError type.
 func (q query) Request() (io.ReadCloser, error) {
+	return q.RequestContext(context.Background())
+}
+
+// RequestContext performs the Request like Request(),
+// but the request is cancelled if the context is cancelled.
+func (q query) RequestContext(ctx context.Context) (io.ReadCloser, error) {
 	url := q.URL()
-	resp, err := http.Get(q.URL())
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
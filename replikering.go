@@ -0,0 +1,270 @@
+package dawa
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Store is a local copy of DAWA data that can be kept up to date by a Replicator.
+//
+// All changes are made through a StoreTx, and the Store must persist the changes
+// of a transaction together with its sekvensnummer atomically.
+// That way a Replicator can always resume from Sekvensnummer() without
+// applying a change twice or missing one.
+type Store interface {
+	// Sekvensnummer returns the sekvensnummer of the last committed transaction.
+	// A store that has never been loaded must return 0.
+	Sekvensnummer() (int64, error)
+
+	// Begin starts a new transaction.
+	Begin() (StoreTx, error)
+}
+
+// StoreTx is a transaction on a Store.
+//
+// Upsert must insert the object, or replace it if an object with the same key exists.
+// Delete must remove the object with the same key as the supplied one, if it exists.
+//
+// The keys are ID for adresser and adgangsadresser, (Kommunekode, Kode) for vejstykker
+// and Nr for postnumre.
+type StoreTx interface {
+	UpsertAdresse(a ReplikeringAdresse) error
+	DeleteAdresse(a ReplikeringAdresse) error
+	UpsertAdgangsAdresse(a ReplikeringAdgangsAdresse) error
+	DeleteAdgangsAdresse(a ReplikeringAdgangsAdresse) error
+	UpsertVejstykke(v ReplikeringVejstykke) error
+	DeleteVejstykke(v ReplikeringVejstykke) error
+	UpsertPostnummer(p ReplikeringPostnummer) error
+	DeletePostnummer(p ReplikeringPostnummer) error
+
+	// Commit persists all changes of the transaction, and sets the sekvensnummer of the store to seq.
+	Commit(seq int64) error
+
+	// Rollback discards all changes of the transaction.
+	Rollback() error
+}
+
+// Replicator keeps a Store up to date with DAWA using the replication API.
+//
+// Use NewReplicator() to get an initialized object.
+// Example:
+//			r := dawa.NewReplicator(dawa.NewMemoryStore())
+//
+//			// Load all data, and keep it updated until ctx is cancelled.
+//			err := r.Run(ctx)
+type Replicator struct {
+	Store     Store         // The store to keep updated.
+	Entities  []Entity      // The entity types to replicate. Default is all types in Entities.
+	Interval  time.Duration // The time between checking for new hændelser in Run(). Default is 1 minute.
+	BatchSize int64         // The maximum number of sekvensnumre to apply in a single transaction. Default is 10000.
+}
+
+// NewReplicator returns a Replicator that will keep the supplied store updated.
+func NewReplicator(s Store) *Replicator {
+	return &Replicator{
+		Store:     s,
+		Entities:  Entities,
+		Interval:  time.Minute,
+		BatchSize: 10000,
+	}
+}
+
+// Run will load the store if it is empty, and apply new hændelser
+// every Interval until the context is cancelled or an error occurs.
+//
+// If the process is stopped, calling Run again will resume from the
+// sekvensnummer stored in the Store.
+func (r *Replicator) Run(ctx context.Context) error {
+	seq, err := r.Store.Sekvensnummer()
+	if err != nil {
+		return err
+	}
+	if seq == 0 {
+		_, err = r.Load(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	for {
+		_, err = r.Sync(ctx)
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.Interval):
+		}
+	}
+}
+
+// Load will perform the initial load of an empty store.
+// The sekvensnummer the store was loaded at is returned.
+//
+// The load is done from a snapshot of all entities at the current
+// sekvensnummer, which is applied in a single transaction.
+// Use Sync() to apply hændelser after that.
+func (r *Replicator) Load(ctx context.Context) (int64, error) {
+	seq, err := r.Store.Sekvensnummer()
+	if err != nil {
+		return 0, err
+	}
+	if seq != 0 {
+		return 0, fmt.Errorf("replicator: store is already loaded to sekvensnummer %d", seq)
+	}
+	seq, err = SenesteSekvensnummer(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := r.Store.Begin()
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range r.entities() {
+		err = r.loadEntity(ctx, tx, e, seq)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+	err = tx.Commit(seq)
+	if err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// loadEntity will add all objects of the entity type at sekvensnummer seq to the transaction.
+func (r *Replicator) loadEntity(ctx context.Context, tx StoreTx, e Entity, seq int64) error {
+	iter, err := Snapshot(ctx, e, seq)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	for {
+		h, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = applyHændelse(tx, h)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Sync will apply all hændelser since the sekvensnummer of the store,
+// until the current sekvensnummer of DAWA.
+// The new sekvensnummer of the store is returned.
+func (r *Replicator) Sync(ctx context.Context) (int64, error) {
+	seq, err := r.Store.Sekvensnummer()
+	if err != nil {
+		return 0, err
+	}
+	latest, err := SenesteSekvensnummer(ctx)
+	if err != nil {
+		return seq, err
+	}
+	batch := r.BatchSize
+	if batch <= 0 {
+		batch = 10000
+	}
+	for seq < latest {
+		to := seq + batch
+		if to > latest {
+			to = latest
+		}
+		err = r.applyRange(ctx, seq+1, to)
+		if err != nil {
+			return seq, err
+		}
+		seq = to
+	}
+	return seq, nil
+}
+
+// applyRange will apply all hændelser with sekvensnummer from 'from' to 'to', both included,
+// in a single transaction.
+func (r *Replicator) applyRange(ctx context.Context, from, to int64) error {
+	var all []Hændelse
+	for _, e := range r.entities() {
+		iter, err := NewHændelseQuery(e).SekvensnummerFra(from).SekvensnummerTil(to).IterContext(ctx)
+		if err != nil {
+			return err
+		}
+		for {
+			h, err := iter.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				iter.Close()
+				return err
+			}
+			all = append(all, *h)
+		}
+		iter.Close()
+	}
+	// Hændelser of different types must be applied in sekvensnummer order.
+	sort.Sort(hændelserBySekvensnummer(all))
+
+	tx, err := r.Store.Begin()
+	if err != nil {
+		return err
+	}
+	for i := range all {
+		err = applyHændelse(tx, &all[i])
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit(to)
+}
+
+func (r *Replicator) entities() []Entity {
+	if len(r.Entities) == 0 {
+		return Entities
+	}
+	return r.Entities
+}
+
+type hændelserBySekvensnummer []Hændelse
+
+func (h hændelserBySekvensnummer) Len() int           { return len(h) }
+func (h hændelserBySekvensnummer) Less(i, j int) bool { return h[i].Sekvensnummer < h[j].Sekvensnummer }
+func (h hændelserBySekvensnummer) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+// applyHændelse will apply a single hændelse to the transaction.
+func applyHændelse(tx StoreTx, h *Hændelse) error {
+	del := h.Operation == OperationDelete
+	switch {
+	case h.Adresse != nil:
+		if del {
+			return tx.DeleteAdresse(*h.Adresse)
+		}
+		return tx.UpsertAdresse(*h.Adresse)
+	case h.AdgangsAdresse != nil:
+		if del {
+			return tx.DeleteAdgangsAdresse(*h.AdgangsAdresse)
+		}
+		return tx.UpsertAdgangsAdresse(*h.AdgangsAdresse)
+	case h.Vejstykke != nil:
+		if del {
+			return tx.DeleteVejstykke(*h.Vejstykke)
+		}
+		return tx.UpsertVejstykke(*h.Vejstykke)
+	case h.Postnummer != nil:
+		if del {
+			return tx.DeletePostnummer(*h.Postnummer)
+		}
+		return tx.UpsertPostnummer(*h.Postnummer)
+	}
+	return fmt.Errorf("replicator: hændelse %d has no data", h.Sekvensnummer)
+}
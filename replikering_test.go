@@ -0,0 +1,266 @@
+package dawa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// replikeringTestEvents contains hændelser for a fake replication server.
+var replikeringTestEvents = map[Entity][]string{
+	EntityPostnummer: {
+		`{"operation":"insert","tidspunkt":"2014-05-05T19:07:48.577Z","sekvensnummer":1,"data":{"nr":8600,"navn":"Silkeborg","stormodtager":false}}`,
+		`{"operation":"update","tidspunkt":"2014-05-05T19:07:49.577Z","sekvensnummer":4,"data":{"nr":8600,"navn":"Silkeborg C","stormodtager":false}}`,
+	},
+	EntityVejstykke: {
+		`{"operation":"insert","tidspunkt":"2014-05-05T19:07:48.577Z","sekvensnummer":2,"data":{"kommunekode":740,"kode":1234,"navn":"Testvej","adresseringsnavn":"Testvej"}}`,
+		`{"operation":"delete","tidspunkt":"2014-05-05T19:07:50.577Z","sekvensnummer":6,"data":{"kommunekode":740,"kode":1234,"navn":"Testvej","adresseringsnavn":"Testvej"}}`,
+	},
+	EntityAdgangsAdresse: {
+		`{"operation":"insert","tidspunkt":"2014-05-05T19:07:48.577Z","sekvensnummer":3,"data":{"id":"0a3f5081-c65d-32b8-e044-0003ba298018","status":1,"kommunekode":740,"vejkode":1234,"husnr":"1","postnr":8600}}`,
+	},
+	EntityAdresse: {
+		`{"operation":"insert","tidspunkt":"2014-05-05T19:07:48.577Z","sekvensnummer":5,"data":{"id":"0a3f50a0-75dc-32b8-e044-0003ba298018","status":1,"adgangsadresseid":"0a3f5081-c65d-32b8-e044-0003ba298018"}}`,
+	},
+}
+
+// replikeringTestSnapshot returns the data of all objects of entity
+// as they were at sekvensnummer seq.
+func replikeringTestSnapshot(entity Entity, seq int64) []string {
+	var keys []string
+	state := make(map[string]string)
+	for _, e := range replikeringTestEvents[entity] {
+		var h struct {
+			Operation     Operation                  `json:"operation"`
+			Sekvensnummer int64                      `json:"sekvensnummer"`
+			Data          map[string]json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(e), &h); err != nil {
+			panic(err)
+		}
+		if h.Sekvensnummer > seq {
+			continue
+		}
+		key := string(h.Data["id"]) + string(h.Data["nr"]) + string(h.Data["kommunekode"]) + string(h.Data["kode"])
+		if _, ok := state[key]; !ok {
+			keys = append(keys, key)
+		}
+		if h.Operation == OperationDelete {
+			state[key] = ""
+			continue
+		}
+		b, _ := json.Marshal(h.Data)
+		state[key] = string(b)
+	}
+	var out []string
+	for _, k := range keys {
+		if state[k] != "" {
+			out = append(out, state[k])
+		}
+	}
+	return out
+}
+
+func replikeringTestServer(t *testing.T, latest int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/replikering/senestesekvensnummer" {
+			fmt.Fprintf(w, `{"sekvensnummer":%d}`, latest)
+			return
+		}
+		path := strings.TrimPrefix(r.URL.Path, "/replikering/")
+		if !strings.HasSuffix(path, "/haendelser") {
+			seq, err := strconv.ParseInt(r.URL.Query().Get("sekvensnummer"), 10, 64)
+			if err != nil {
+				http.Error(w, "missing sekvensnummer", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, "["+strings.Join(replikeringTestSnapshot(Entity(path), seq), ",")+"]")
+			return
+		}
+		entity := Entity(strings.TrimSuffix(path, "/haendelser"))
+		from, _ := strconv.ParseInt(r.URL.Query().Get("sekvensnummerfra"), 10, 64)
+		to, err := strconv.ParseInt(r.URL.Query().Get("sekvensnummertil"), 10, 64)
+		if err != nil {
+			to = latest
+		}
+		var out []string
+		for _, e := range replikeringTestEvents[entity] {
+			var seq int64
+			fmt.Sscanf(e[strings.Index(e, `"sekvensnummer":`)+len(`"sekvensnummer":`):], "%d", &seq)
+			if seq >= from && seq <= to {
+				out = append(out, e)
+			}
+		}
+		fmt.Fprint(w, "["+strings.Join(out, ",")+"]")
+	}))
+}
+
+func TestReplicatorSync(t *testing.T) {
+	ts := replikeringTestServer(t, 6)
+	defer ts.Close()
+	old := DefaultHost
+	DefaultHost = ts.URL
+	defer func() { DefaultHost = old }()
+
+	store := NewMemoryStore()
+	r := NewReplicator(store)
+	r.BatchSize = 4
+	seq, err := r.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq != 6 {
+		t.Fatalf("expected sekvensnummer 6, got %d", seq)
+	}
+	p, ok := store.Postnummer(8600)
+	if !ok || p.Navn != "Silkeborg C" {
+		t.Fatalf("unexpected postnummer: %#v", p)
+	}
+	if _, ok := store.Vejstykke(740, 1234); ok {
+		t.Fatal("vejstykke should have been deleted")
+	}
+	if store.Len(EntityAdgangsAdresse) != 1 || store.Len(EntityAdresse) != 1 {
+		t.Fatalf("unexpected number of adresser: %d, %d", store.Len(EntityAdgangsAdresse), store.Len(EntityAdresse))
+	}
+	if _, err = r.Load(context.Background()); err == nil {
+		t.Fatal("expected error loading a loaded store")
+	}
+}
+
+func TestReplicatorLoadThenSync(t *testing.T) {
+	ts := replikeringTestServer(t, 4)
+	defer ts.Close()
+	old := DefaultHost
+	DefaultHost = ts.URL
+	defer func() { DefaultHost = old }()
+
+	store := NewMemoryStore()
+	r := NewReplicator(store)
+	seq, err := r.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq != 4 {
+		t.Fatalf("expected sekvensnummer 4, got %d", seq)
+	}
+	if _, ok := store.Vejstykke(740, 1234); !ok {
+		t.Fatal("vejstykke should be present in snapshot at 4")
+	}
+	if store.Len(EntityAdresse) != 0 {
+		t.Fatal("adresse should not be present in snapshot at 4")
+	}
+
+	ts2 := replikeringTestServer(t, 6)
+	defer ts2.Close()
+	DefaultHost = ts2.URL
+	seq, err = r.Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq != 6 {
+		t.Fatalf("expected sekvensnummer 6, got %d", seq)
+	}
+	if _, ok := store.Vejstykke(740, 1234); ok {
+		t.Fatal("vejstykke should have been deleted")
+	}
+	if store.Len(EntityAdresse) != 1 {
+		t.Fatal("adresse should have been added")
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	ts := replikeringTestServer(t, 6)
+	defer ts.Close()
+	old := DefaultHost
+	DefaultHost = ts.URL
+	defer func() { DefaultHost = old }()
+
+	seq, err := SenesteSekvensnummer(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	iter, err := Snapshot(context.Background(), EntityPostnummer, seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close()
+	h, err := iter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Postnummer == nil || h.Postnummer.Navn != "Silkeborg C" || h.Sekvensnummer != 6 || h.Operation != OperationInsert {
+		t.Fatalf("unexpected snapshot entry: %#v", h)
+	}
+	if _, err = iter.Next(); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+	if _, err = Snapshot(context.Background(), Entity("unknown"), seq); err == nil {
+		t.Fatal("expected error on unknown entity")
+	}
+}
+
+// failingStore fails to commit when the sekvensnummer reaches failAt.
+type failingStore struct {
+	*MemoryStore
+	failAt int64
+}
+
+func (f *failingStore) Begin() (StoreTx, error) {
+	tx, err := f.MemoryStore.Begin()
+	return &failingTx{StoreTx: tx, failAt: f.failAt}, err
+}
+
+type failingTx struct {
+	StoreTx
+	failAt int64
+}
+
+func (f *failingTx) Commit(seq int64) error {
+	if seq >= f.failAt {
+		f.Rollback()
+		return errors.New("commit failed")
+	}
+	return f.StoreTx.Commit(seq)
+}
+
+func TestReplicatorResume(t *testing.T) {
+	ts := replikeringTestServer(t, 6)
+	defer ts.Close()
+	old := DefaultHost
+	DefaultHost = ts.URL
+	defer func() { DefaultHost = old }()
+
+	store := &failingStore{MemoryStore: NewMemoryStore(), failAt: 4}
+	r := NewReplicator(store)
+	r.BatchSize = 2
+	seq, err := r.Sync(context.Background())
+	if err == nil {
+		t.Fatal("expected commit error")
+	}
+	if seq != 2 {
+		t.Fatalf("expected sekvensnummer 2 after failure, got %d", seq)
+	}
+	if store.Len(EntityAdgangsAdresse) != 0 {
+		t.Fatal("uncommitted changes were applied")
+	}
+
+	// Resume from the stored sekvensnummer.
+	store.failAt = 100
+	seq, err = r.Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq != 6 {
+		t.Fatalf("expected sekvensnummer 6, got %d", seq)
+	}
+	if store.Len(EntityAdgangsAdresse) != 1 || store.Len(EntityAdresse) != 1 || store.Len(EntityPostnummer) != 1 {
+		t.Fatal("unexpected store content after resume")
+	}
+}
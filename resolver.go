@@ -0,0 +1,657 @@
+package dawa
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Resolver resolves references to full objects, and finds related objects.
+//
+// Use APIResolver to resolve using the DAWA server, or IndexResolver
+// to resolve using a local Index.
+// All Resolve methods on references accept a nil Resolver, which will use the API.
+//
+// Lookups of single objects return (nil, io.EOF) if the object cannot be found.
+// Lookups of related objects return an empty list if none can be found.
+//
+// Example:
+//			r := dawa.IndexResolver{Index: ix}
+//			k, err := a.Kommune.Resolve(r)
+//			veje, err := k.Vejstykker(r)
+//			for _, v := range veje {
+//				adgangsadresser, err := v.AdgangsAdresser(r)
+//				...
+//			}
+type Resolver interface {
+	// Kommune returns the kommune with the specified kode.
+	Kommune(kode string) (*Kommune, error)
+
+	// Postnummer returns the postnummer with the specified nr.
+	Postnummer(nr string) (*Postnummer, error)
+
+	// Vejstykke returns the vejstykke with the specified kommunekode and vejkode.
+	Vejstykke(kommunekode, kode string) (*Vejstykke, error)
+
+	// AdgangsAdresse returns the adgangsadresse with the specified ID.
+	AdgangsAdresse(id string) (*AdgangsAdresse, error)
+
+	// Adresse returns the adresse with the specified ID.
+	Adresse(id string) (*Adresse, error)
+
+	// Område returns the area of the list type with the specified key.
+	// The type of the returned value is the same as ListQuery.Type() for the list type.
+	// The key is the 'kode' of the area, except for "storkredse" where it is the 'nummer',
+	// "valglandsdele" where it is the 'bogstav', "landsdele" where it is the 'nuts3',
+	// and "afstemningsomraader" and "menighedsraadsafstemningsomraader" where it is
+	// the kommunekode and nummer separated by a slash, like "0101/12".
+	Område(listType, key string) (interface{}, error)
+
+	// Vejstykker returns all vejstykker in the kommune with the specified kode.
+	Vejstykker(kommunekode string) ([]Vejstykke, error)
+
+	// AdgangsAdresser returns all adgangsadresser on the vejstykke with the specified kommunekode and vejkode.
+	AdgangsAdresser(kommunekode, vejkode string) ([]AdgangsAdresse, error)
+
+	// Adresser returns all adresser on the adgangsadresse with the specified ID.
+	Adresser(adgangsadresseID string) ([]Adresse, error)
+
+	// SupplerendeBynavne returns all supplerende bynavne in the postnummer with the specified nr.
+	SupplerendeBynavne(postnr string) ([]SupplBynavn, error)
+}
+
+// resolver returns r, or an APIResolver if r is nil.
+func resolver(r Resolver) Resolver {
+	if r == nil {
+		return APIResolver{}
+	}
+	return r
+}
+
+// Resolve returns the full kommune. If r is nil, the API is used.
+func (k KommuneRef) Resolve(r Resolver) (*Kommune, error) {
+	return resolver(r).Kommune(k.Kode)
+}
+
+// Vejstykker returns all vejstykker in the kommune. If r is nil, the API is used.
+func (k KommuneRef) Vejstykker(r Resolver) ([]Vejstykke, error) {
+	return resolver(r).Vejstykker(k.Kode)
+}
+
+// Resolve returns the full postnummer. If r is nil, the API is used.
+func (p PostnummerRef) Resolve(r Resolver) (*Postnummer, error) {
+	return resolver(r).Postnummer(p.Nr)
+}
+
+// SupplerendeBynavne returns all supplerende bynavne in the postnummer. If r is nil, the API is used.
+func (p PostnummerRef) SupplerendeBynavne(r Resolver) ([]SupplBynavn, error) {
+	return resolver(r).SupplerendeBynavne(p.Nr)
+}
+
+// SupplerendeBynavne returns all supplerende bynavne in the postnummer. If r is nil, the API is used.
+func (p Postnummer) SupplerendeBynavne(r Resolver) ([]SupplBynavn, error) {
+	return resolver(r).SupplerendeBynavne(p.Nr)
+}
+
+// Resolve returns the full vejstykke. If r is nil, the API is used.
+// Since the vejkode is only unique within a kommune, the kommunekode must be supplied.
+func (v VejstykkeRef) Resolve(r Resolver, kommunekode string) (*Vejstykke, error) {
+	return resolver(r).Vejstykke(kommunekode, v.Kode)
+}
+
+// AdgangsAdresser returns all adgangsadresser on the vejstykke. If r is nil, the API is used.
+func (v Vejstykke) AdgangsAdresser(r Resolver) ([]AdgangsAdresse, error) {
+	return resolver(r).AdgangsAdresser(v.Kommune.Kode, v.Kode)
+}
+
+// Resolve returns the full adgangsadresse. If r is nil, the API is used.
+func (a AdgangsAdresseRef) Resolve(r Resolver) (*AdgangsAdresse, error) {
+	return resolver(r).AdgangsAdresse(a.ID)
+}
+
+// Adresser returns all adresser on the adgangsadresse. If r is nil, the API is used.
+func (a AdgangsAdresse) Adresser(r Resolver) ([]Adresse, error) {
+	return resolver(r).Adresser(a.ID)
+}
+
+// Resolve returns the full region. If r is nil, the API is used.
+func (o RegionRef) Resolve(r Resolver) (*Region, error) {
+	v, err := resolver(r).Område("regioner", o.Kode)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Region), nil
+}
+
+// Resolve returns the full sogn. If r is nil, the API is used.
+func (o SognRef) Resolve(r Resolver) (*Sogn, error) {
+	v, err := resolver(r).Område("sogne", o.Kode)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Sogn), nil
+}
+
+// Resolve returns the full politikreds. If r is nil, the API is used.
+func (o PolitikredsRef) Resolve(r Resolver) (*Politikreds, error) {
+	v, err := resolver(r).Område("politikredse", o.Kode)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Politikreds), nil
+}
+
+// Resolve returns the full retskreds. If r is nil, the API is used.
+func (o RetskredsRef) Resolve(r Resolver) (*Retskreds, error) {
+	v, err := resolver(r).Område("retskredse", o.Kode)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Retskreds), nil
+}
+
+// Resolve returns the full opstillingskreds. If r is nil, the API is used.
+func (o OpstillingskredsRef) Resolve(r Resolver) (*Opstillingskreds, error) {
+	v, err := resolver(r).Område("opstillingskredse", o.Kode)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Opstillingskreds), nil
+}
+
+// Resolve returns the full storkreds. If r is nil, the API is used.
+func (o StorkredsRef) Resolve(r Resolver) (*Storkreds, error) {
+	v, err := resolver(r).Område("storkredse", o.Nummer)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Storkreds), nil
+}
+
+// Resolve returns the full valglandsdel. If r is nil, the API is used.
+func (o ValglandsdelRef) Resolve(r Resolver) (*Valglandsdel, error) {
+	v, err := resolver(r).Område("valglandsdele", o.Bogstav)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Valglandsdel), nil
+}
+
+// Resolve returns the full landsdel. If r is nil, the API is used.
+func (o LandsdelRef) Resolve(r Resolver) (*Landsdel, error) {
+	v, err := resolver(r).Område("landsdele", o.Nuts3)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Landsdel), nil
+}
+
+// Resolve returns the full afstemningsområde. If r is nil, the API is used.
+// Since the nummer is only unique within a kommune, the kommunekode must be supplied.
+func (o AfstemningsområdeRef) Resolve(r Resolver, kommunekode string) (*Afstemningsområde, error) {
+	v, err := resolver(r).Område("afstemningsomraader", kommunekode+"/"+o.Nummer)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Afstemningsområde), nil
+}
+
+// Resolve returns the full menighedsrådsafstemningsområde. If r is nil, the API is used.
+// Since the nummer is only unique within a kommune, the kommunekode must be supplied.
+func (o MenighedsrådsafstemningsområdeRef) Resolve(r Resolver, kommunekode string) (*Menighedsrådsafstemningsområde, error) {
+	v, err := resolver(r).Område("menighedsraadsafstemningsomraader", kommunekode+"/"+o.Nummer)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Menighedsrådsafstemningsområde), nil
+}
+
+// APIResolver is a Resolver that requests objects from the DAWA server at DefaultHost.
+type APIResolver struct{}
+
+// Kommune returns the kommune with the specified kode.
+func (r APIResolver) Kommune(kode string) (*Kommune, error) {
+	v, err := r.Område("kommuner", kode)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Kommune), nil
+}
+
+// Postnummer returns the postnummer with the specified nr.
+func (r APIResolver) Postnummer(nr string) (*Postnummer, error) {
+	return GetPostnr(nr)
+}
+
+// Vejstykke returns the vejstykke with the specified kommunekode and vejkode.
+func (r APIResolver) Vejstykke(kommunekode, kode string) (*Vejstykke, error) {
+	res, err := r.vejstykker(kommunekode, kode)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, io.EOF
+	}
+	return &res[0], nil
+}
+
+// AdgangsAdresse returns the adgangsadresse with the specified ID.
+func (r APIResolver) AdgangsAdresse(id string) (*AdgangsAdresse, error) {
+	return GetAAID(id)
+}
+
+// Adresse returns the adresse with the specified ID.
+func (r APIResolver) Adresse(id string) (*Adresse, error) {
+	return GetAdresseID(id)
+}
+
+// Område returns the area of the list type with the specified key.
+// See Resolver for the format of the key.
+func (r APIResolver) Område(listType, key string) (interface{}, error) {
+	q := NewListQuery(listType, false)
+	if q.Type() == nil {
+		return nil, fmt.Errorf("unknown list type '%s'", listType)
+	}
+	switch listType {
+	case "storkredse":
+		q.Add("nummer", key)
+	case "valglandsdele":
+		q.Add("bogstav", key)
+	case "landsdele":
+		q.Add("nuts3", key)
+	case "afstemningsomraader", "menighedsraadsafstemningsomraader":
+		i := strings.Index(key, "/")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid key '%s', expected kommunekode/nummer", key)
+		}
+		q.Add("kommunekode", key[:i])
+		q.Add("nummer", key[i+1:])
+	default:
+		q.Kode(key)
+	}
+	iter, err := q.Iter()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	return iter.Next()
+}
+
+// Vejstykker returns all vejstykker in the kommune with the specified kode.
+func (r APIResolver) Vejstykker(kommunekode string) ([]Vejstykke, error) {
+	return r.vejstykker(kommunekode, "")
+}
+
+// AdgangsAdresser returns all adgangsadresser on the vejstykke with the specified kommunekode and vejkode.
+func (r APIResolver) AdgangsAdresser(kommunekode, vejkode string) ([]AdgangsAdresse, error) {
+	return NewAdgangsAdresseQuery().Kommunekode(kommunekode).Vejkode(vejkode).All()
+}
+
+// Adresser returns all adresser on the adgangsadresse with the specified ID.
+func (r APIResolver) Adresser(adgangsadresseID string) ([]Adresse, error) {
+	return NewAdresseQuery().AdgangsadresseID(adgangsadresseID).All()
+}
+
+// SupplerendeBynavne returns all supplerende bynavne in the postnummer with the specified nr.
+func (r APIResolver) SupplerendeBynavne(postnr string) ([]SupplBynavn, error) {
+	q := query{host: DefaultHost, path: "/supplerendebynavne"}
+	q.Add("postnr", postnr)
+	q.add(&textQuery{Name: "noformat", Multi: false, Null: true})
+	resp, err := q.Request()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	iter, err := ImportSupplBynavnJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]SupplBynavn, 0)
+	for {
+		v, err := iter.Next()
+		if err == io.EOF {
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *v)
+	}
+}
+
+// vejstykker returns the vejstykker in the kommune.
+// If kode is not empty, only the vejstykke with that kode is returned.
+func (r APIResolver) vejstykker(kommunekode, kode string) ([]Vejstykke, error) {
+	q := query{host: DefaultHost, path: "/vejstykker"}
+	q.Add("kommunekode", kommunekode)
+	if kode != "" {
+		q.Add("kode", kode)
+	}
+	q.add(&textQuery{Name: "noformat", Multi: false, Null: true})
+	resp, err := q.Request()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	iter, err := ImportVejstykkerJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Vejstykke, 0)
+	for {
+		v, err := iter.Next()
+		if err == io.EOF {
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *v)
+	}
+}
+
+// IndexResolver is a Resolver that uses a local Index, and optionally an OmrådeIndex.
+//
+// Adgangsadresser and adresser are returned as they are in the index.
+// Kommuner, vejstykker, postnumre and supplerende bynavne are built from
+// the adgangsadresser in the index, so only their codes, names and relations are filled.
+// If a kommune or postnummer has no adgangsadresser, it is looked up in the OmrådeIndex.
+//
+// Areas are looked up in the OmrådeIndex. Only the code and name of the areas are filled,
+// and areas without a 'kode' cannot be resolved.
+type IndexResolver struct {
+	Index   *Index       // Required. Methods that use the index return ErrNoIndex if it is nil.
+	Områder *OmrådeIndex // Optional.
+}
+
+// ErrNoIndex is returned by IndexResolver if no Index is set.
+var ErrNoIndex = errors.New("resolver: IndexResolver has no Index")
+
+// Kommune returns the kommune with the specified kode.
+func (r IndexResolver) Kommune(kode string) (*Kommune, error) {
+	if r.Index == nil {
+		return nil, ErrNoIndex
+	}
+	k, err := strconv.Atoi(kode)
+	if err != nil {
+		return nil, io.EOF
+	}
+	if v := r.Index.Kommune(k); v != nil {
+		return v, nil
+	}
+	v, err := r.Område("kommuner", kode)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Kommune), nil
+}
+
+// Postnummer returns the postnummer with the specified nr.
+func (r IndexResolver) Postnummer(nr string) (*Postnummer, error) {
+	if r.Index == nil {
+		return nil, ErrNoIndex
+	}
+	n, err := strconv.Atoi(nr)
+	if err != nil {
+		return nil, io.EOF
+	}
+	list := r.Index.AdgangsAdresserPostnr(n)
+	if len(list) == 0 {
+		if r.Områder == nil {
+			return nil, io.EOF
+		}
+		o := r.Områder.Område("postnumre", nr)
+		if o == nil {
+			return nil, io.EOF
+		}
+		return &Postnummer{Nr: o.Kode, Navn: o.Navn}, nil
+	}
+	p := &Postnummer{Nr: list[0].Postnummer.Nr, Navn: list[0].Postnummer.Navn}
+	seen := make(map[string]bool)
+	for _, a := range list {
+		if !seen[a.Kommune.Kode] {
+			seen[a.Kommune.Kode] = true
+			p.Kommuner = append(p.Kommuner, KommuneRef{Kode: a.Kommune.Kode, Navn: a.Kommune.Navn})
+		}
+	}
+	sort.Sort(kommuneRefByKode(p.Kommuner))
+	return p, nil
+}
+
+// Vejstykke returns the vejstykke with the specified kommunekode and vejkode.
+func (r IndexResolver) Vejstykke(kommunekode, kode string) (*Vejstykke, error) {
+	if r.Index == nil {
+		return nil, ErrNoIndex
+	}
+	k, err := strconv.Atoi(kommunekode)
+	if err != nil {
+		return nil, io.EOF
+	}
+	v, err := strconv.Atoi(kode)
+	if err != nil {
+		return nil, io.EOF
+	}
+	list := r.Index.AdgangsAdresserVejstykke(k, v)
+	if len(list) == 0 {
+		return nil, io.EOF
+	}
+	res := indexVejstykke(list)
+	return &res, nil
+}
+
+// AdgangsAdresse returns the adgangsadresse with the specified ID.
+func (r IndexResolver) AdgangsAdresse(id string) (*AdgangsAdresse, error) {
+	if r.Index == nil {
+		return nil, ErrNoIndex
+	}
+	a := r.Index.AdgangsAdresse(id)
+	if a == nil {
+		return nil, io.EOF
+	}
+	v := *a
+	return &v, nil
+}
+
+// Adresse returns the adresse with the specified ID.
+func (r IndexResolver) Adresse(id string) (*Adresse, error) {
+	if r.Index == nil {
+		return nil, ErrNoIndex
+	}
+	a := r.Index.Adresse(id)
+	if a == nil {
+		return nil, io.EOF
+	}
+	v := *a
+	return &v, nil
+}
+
+// Område returns the area of the list type with the specified key.
+// Only "kommuner", "postnumre", "regioner", "sogne", "politikredse", "retskredse" and "opstillingskredse"
+// can be resolved. If there is no OmrådeIndex, (nil, io.EOF) is returned.
+func (r IndexResolver) Område(listType, key string) (interface{}, error) {
+	if r.Områder == nil {
+		return nil, io.EOF
+	}
+	o := r.Områder.Område(listType, key)
+	if o == nil {
+		return nil, io.EOF
+	}
+	switch listType {
+	case "kommuner":
+		return &Kommune{KommuneRef: KommuneRef{Kode: o.Kode, Navn: o.Navn}}, nil
+	case "postnumre":
+		return &Postnummer{Nr: o.Kode, Navn: o.Navn}, nil
+	case "regioner":
+		return &Region{RegionRef: RegionRef{Kode: o.Kode, Navn: o.Navn}}, nil
+	case "sogne":
+		return &Sogn{SognRef: SognRef{Kode: o.Kode, Navn: o.Navn}}, nil
+	case "politikredse":
+		return &Politikreds{PolitikredsRef: PolitikredsRef{Kode: o.Kode, Navn: o.Navn}}, nil
+	case "retskredse":
+		return &Retskreds{RetskredsRef: RetskredsRef{Kode: o.Kode, Navn: o.Navn}}, nil
+	case "opstillingskredse":
+		return &Opstillingskreds{OpstillingskredsRef: OpstillingskredsRef{Kode: o.Kode, Navn: o.Navn}}, nil
+	}
+	return nil, fmt.Errorf("list type '%s' cannot be resolved locally", listType)
+}
+
+// Vejstykker returns all vejstykker in the kommune with the specified kode, ordered by vejkode.
+func (r IndexResolver) Vejstykker(kommunekode string) ([]Vejstykke, error) {
+	if r.Index == nil {
+		return nil, ErrNoIndex
+	}
+	res := make([]Vejstykke, 0)
+	k, err := strconv.Atoi(kommunekode)
+	if err != nil {
+		return res, nil
+	}
+	for key, list := range r.Index.vejstykker {
+		if key.kommune == k {
+			res = append(res, indexVejstykke(list))
+		}
+	}
+	sort.Sort(vejstykkeByKode(res))
+	return res, nil
+}
+
+// AdgangsAdresser returns all adgangsadresser on the vejstykke with the specified kommunekode and vejkode,
+// ordered by husnr.
+func (r IndexResolver) AdgangsAdresser(kommunekode, vejkode string) ([]AdgangsAdresse, error) {
+	if r.Index == nil {
+		return nil, ErrNoIndex
+	}
+	res := make([]AdgangsAdresse, 0)
+	k, err := strconv.Atoi(kommunekode)
+	if err != nil {
+		return res, nil
+	}
+	v, err := strconv.Atoi(vejkode)
+	if err != nil {
+		return res, nil
+	}
+	for _, a := range r.Index.AdgangsAdresserVejstykke(k, v) {
+		res = append(res, *a)
+	}
+	sort.Sort(adgangsAdresseByHusnr(res))
+	return res, nil
+}
+
+// Adresser returns all adresser on the adgangsadresse with the specified ID, ordered by etage and dør.
+func (r IndexResolver) Adresser(adgangsadresseID string) ([]Adresse, error) {
+	if r.Index == nil {
+		return nil, ErrNoIndex
+	}
+	res := make([]Adresse, 0)
+	for _, a := range r.Index.AdresserAdgangsAdresse(adgangsadresseID) {
+		res = append(res, *a)
+	}
+	sort.Sort(adresseByEtage(res))
+	return res, nil
+}
+
+// SupplerendeBynavne returns all supplerende bynavne in the postnummer with the specified nr, ordered by name.
+// Only the postnummer and kommuner of the adgangsadresser in the postnummer are included.
+func (r IndexResolver) SupplerendeBynavne(postnr string) ([]SupplBynavn, error) {
+	if r.Index == nil {
+		return nil, ErrNoIndex
+	}
+	res := make([]SupplBynavn, 0)
+	n, err := strconv.Atoi(postnr)
+	if err != nil {
+		return res, nil
+	}
+	found := make(map[string]int)
+	for _, a := range r.Index.AdgangsAdresserPostnr(n) {
+		if a.SupplerendeBynavn == "" {
+			continue
+		}
+		i, ok := found[a.SupplerendeBynavn]
+		if !ok {
+			i = len(res)
+			found[a.SupplerendeBynavn] = i
+			res = append(res, SupplBynavn{
+				Navn:      a.SupplerendeBynavn,
+				Postnumre: []PostnummerRef{{Nr: a.Postnummer.Nr, Navn: a.Postnummer.Navn}},
+			})
+		}
+		b := &res[i]
+		known := false
+		for _, k := range b.Kommuner {
+			if k.Kode == a.Kommune.Kode {
+				known = true
+				break
+			}
+		}
+		if !known {
+			b.Kommuner = append(b.Kommuner, KommuneRef{Kode: a.Kommune.Kode, Navn: a.Kommune.Navn})
+		}
+	}
+	for _, b := range res {
+		sort.Sort(kommuneRefByKode(b.Kommuner))
+	}
+	sort.Sort(supplBynavnByNavn(res))
+	return res, nil
+}
+
+// indexVejstykke returns a vejstykke built from the adgangsadresser on it.
+func indexVejstykke(list []*AdgangsAdresse) Vejstykke {
+	a := list[0]
+	v := Vejstykke{
+		Kode:    a.Vejstykke.Kode,
+		Navn:    a.Vejstykke.Navn,
+		Kommune: KommuneRef{Kode: a.Kommune.Kode, Navn: a.Kommune.Navn},
+	}
+	seen := make(map[string]bool)
+	for _, a := range list {
+		if a.Postnummer.Nr != "" && !seen[a.Postnummer.Nr] {
+			seen[a.Postnummer.Nr] = true
+			v.Postnumre = append(v.Postnumre, PostnummerRef{Nr: a.Postnummer.Nr, Navn: a.Postnummer.Navn})
+		}
+	}
+	sort.Sort(postnummerRefByNr(v.Postnumre))
+	return v
+}
+
+type kommuneRefByKode []KommuneRef
+
+func (k kommuneRefByKode) Len() int           { return len(k) }
+func (k kommuneRefByKode) Less(i, j int) bool { return k[i].Kode < k[j].Kode }
+func (k kommuneRefByKode) Swap(i, j int)      { k[i], k[j] = k[j], k[i] }
+
+type postnummerRefByNr []PostnummerRef
+
+func (p postnummerRefByNr) Len() int           { return len(p) }
+func (p postnummerRefByNr) Less(i, j int) bool { return p[i].Nr < p[j].Nr }
+func (p postnummerRefByNr) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+type vejstykkeByKode []Vejstykke
+
+func (v vejstykkeByKode) Len() int           { return len(v) }
+func (v vejstykkeByKode) Less(i, j int) bool { return v[i].Kode < v[j].Kode }
+func (v vejstykkeByKode) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+
+type supplBynavnByNavn []SupplBynavn
+
+func (s supplBynavnByNavn) Len() int           { return len(s) }
+func (s supplBynavnByNavn) Less(i, j int) bool { return s[i].Navn < s[j].Navn }
+func (s supplBynavnByNavn) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+type adgangsAdresseByHusnr []AdgangsAdresse
+
+func (a adgangsAdresseByHusnr) Len() int           { return len(a) }
+func (a adgangsAdresseByHusnr) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a adgangsAdresseByHusnr) Less(i, j int) bool { return a[i].Husnr.Less(a[j].Husnr) }
+
+type adresseByEtage []Adresse
+
+func (a adresseByEtage) Len() int      { return len(a) }
+func (a adresseByEtage) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a adresseByEtage) Less(i, j int) bool {
+	if c := CompareEtage(a[i].Etage, a[j].Etage); c != 0 {
+		return c < 0
+	}
+	return a[i].Dør.Less(a[j].Dør)
+}
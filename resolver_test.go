@@ -0,0 +1,146 @@
+package dawa
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var resolver_adresse_input = `[
+{"id":"ad1","etage":"1","dør":"tv","adgangsadresse":{"id":"aa1","husnr":"46","kommune":{"kode":"0101","navn":"København"},"region":{"kode":"1084","navn":"Region Hovedstaden"},"vejstykke":{"kode":"6100","navn":"Rødkildevej"},"postnummer":{"nr":"2400","navn":"København NV"}}},
+{"id":"ad2","etage":"st","dør":"th","adgangsadresse":{"id":"aa1","husnr":"46","kommune":{"kode":"0101","navn":"København"},"region":{"kode":"1084","navn":"Region Hovedstaden"},"vejstykke":{"kode":"6100","navn":"Rødkildevej"},"postnummer":{"nr":"2400","navn":"København NV"}}},
+{"id":"ad3","etage":"","dør":"","adgangsadresse":{"id":"aa2","husnr":"8","kommune":{"kode":"0101","navn":"København"},"vejstykke":{"kode":"6100","navn":"Rødkildevej"},"postnummer":{"nr":"2400","navn":"København NV"}}},
+{"id":"ad4","etage":"","dør":"","adgangsadresse":{"id":"aa3","husnr":"4","kommune":{"kode":"0101","navn":"København"},"vejstykke":{"kode":"0004","navn":"Abel Cathrines Gade"},"postnummer":{"nr":"1654","navn":"København V"}}},
+{"id":"ad5","etage":"","dør":"","adgangsadresse":{"id":"aa4","husnr":"4","supplerendebynavn":"Øster Assels","kommune":{"kode":"0773","navn":"Morsø"},"vejstykke":{"kode":"0010","navn":"Ådalen"},"postnummer":{"nr":"7990","navn":"Øster Assels"}}},
+{"id":"ad6","etage":"","dør":"","adgangsadresse":{"id":"aa5","husnr":"2","supplerendebynavn":"Ljørslev","kommune":{"kode":"0773","navn":"Morsø"},"vejstykke":{"kode":"0020","navn":"Ljørslevvej"},"postnummer":{"nr":"7990","navn":"Øster Assels"}}}
+]`
+
+func TestIndexResolver(t *testing.T) {
+	ix := NewIndex()
+	iter, err := ImportAdresserJSON(bytes.NewBufferString(resolver_adresse_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ix.LoadAdresser(iter); err != nil {
+		t.Fatal(err)
+	}
+	oi := NewOmrådeIndex()
+	oi.Add(Område{Type: "sogne", Kode: "7038", Navn: "Brønshøj", Geometri: MultiPolygon{{{{12.5, 55.6}, {12.6, 55.6}, {12.6, 55.7}, {12.5, 55.6}}}}})
+	r := IndexResolver{Index: ix, Områder: oi}
+
+	aa, err := AdgangsAdresseRef{ID: "aa1"}.Resolve(r)
+	if err != nil || aa.Husnr != "46" {
+		t.Fatalf("unexpected result: %#v, %v", aa, err)
+	}
+	k, err := aa.Kommune.Resolve(r)
+	if err != nil || k.Kode != "0101" || k.Navn != "København" || k.Regionskode != "1084" {
+		t.Fatalf("unexpected kommune: %#v, %v", k, err)
+	}
+	veje, err := k.Vejstykker(r)
+	if err != nil || len(veje) != 2 || veje[0].Navn != "Abel Cathrines Gade" || veje[1].Kode != "6100" {
+		t.Fatalf("unexpected vejstykker: %#v, %v", veje, err)
+	}
+	if len(veje[1].Postnumre) != 1 || veje[1].Postnumre[0].Nr != "2400" || veje[1].Kommune.Kode != "0101" {
+		t.Fatalf("unexpected vejstykke: %#v", veje[1])
+	}
+	aas, err := veje[1].AdgangsAdresser(r)
+	if err != nil || len(aas) != 2 || aas[0].Husnr != "8" || aas[1].Husnr != "46" {
+		t.Fatalf("unexpected adgangsadresser: %#v, %v", aas, err)
+	}
+	ads, err := aas[1].Adresser(r)
+	if err != nil || len(ads) != 2 {
+		t.Fatalf("unexpected adresser: %#v, %v", ads, err)
+	}
+	v, err := aa.Vejstykke.Resolve(r, aa.Kommune.Kode)
+	if err != nil || v.Navn != "Rødkildevej" {
+		t.Fatalf("unexpected vejstykke: %#v, %v", v, err)
+	}
+
+	p, err := PostnummerRef{Nr: "7990"}.Resolve(r)
+	if err != nil || p.Navn != "Øster Assels" || len(p.Kommuner) != 1 || p.Kommuner[0].Kode != "0773" {
+		t.Fatalf("unexpected postnummer: %#v, %v", p, err)
+	}
+	byer, err := p.SupplerendeBynavne(r)
+	if err != nil || len(byer) != 2 || byer[0].Navn != "Ljørslev" || byer[1].Navn != "Øster Assels" {
+		t.Fatalf("unexpected supplerende bynavne: %#v, %v", byer, err)
+	}
+	if byer[0].Postnumre[0].Nr != "7990" || byer[0].Kommuner[0].Navn != "Morsø" {
+		t.Fatalf("unexpected supplerende bynavn: %#v", byer[0])
+	}
+
+	s, err := SognRef{Kode: "7038"}.Resolve(r)
+	if err != nil || s.Navn != "Brønshøj" {
+		t.Fatalf("unexpected sogn: %#v, %v", s, err)
+	}
+
+	// Not found
+	if _, err := (KommuneRef{Kode: "0999"}).Resolve(r); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+	if _, err := (AdgangsAdresseRef{ID: "none"}).Resolve(r); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+	if _, err := (RegionRef{Kode: "1084"}).Resolve(r); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+	if veje, err := (KommuneRef{Kode: "0999"}).Vejstykker(r); err != nil || len(veje) != 0 {
+		t.Fatalf("expected no vejstykker, got %#v, %v", veje, err)
+	}
+
+	// A resolver without an index returns an error instead of panicking.
+	var empty IndexResolver
+	if _, err := (KommuneRef{Kode: "0101"}).Resolve(empty); err != ErrNoIndex {
+		t.Fatalf("expected ErrNoIndex, got %v", err)
+	}
+	if _, err := empty.Adresser("aa1"); err != ErrNoIndex {
+		t.Fatalf("expected ErrNoIndex, got %v", err)
+	}
+}
+
+func TestAPIResolver(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path == "/vejstykker" && q.Get("kommunekode") == "0101" && q.Get("kode") == "6100":
+			w.Write([]byte(`[{"kode":"6100","navn":"Rødkildevej","kommune":{"kode":"0101","navn":"København"}}]`))
+		case r.URL.Path == "/kommuner" && q.Get("kode") == "0101":
+			w.Write([]byte(`[{"kode":"0101","navn":"København","regionskode":"1084"}]`))
+		case r.URL.Path == "/storkredse" && q.Get("nummer") == "1":
+			w.Write([]byte(`[{"nummer":"1","navn":"København","regionskode":"1084"}]`))
+		case r.URL.Path == "/supplerendebynavne" && q.Get("postnr") == "7990":
+			w.Write([]byte(`[{"navn":"Ljørslev"},{"navn":"Øster Assels"}]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer ts.Close()
+	old := DefaultHost
+	DefaultHost = ts.URL
+	defer func() { DefaultHost = old }()
+
+	v, err := VejstykkeRef{Kode: "6100"}.Resolve(nil, "0101")
+	if err != nil || v.Navn != "Rødkildevej" {
+		t.Fatalf("unexpected vejstykke: %#v, %v", v, err)
+	}
+	k, err := v.Kommune.Resolve(nil)
+	if err != nil || k.Regionskode != "1084" {
+		t.Fatalf("unexpected kommune: %#v, %v", k, err)
+	}
+	s, err := StorkredsRef{Nummer: "1"}.Resolve(APIResolver{})
+	if err != nil || s.Navn != "København" {
+		t.Fatalf("unexpected storkreds: %#v, %v", s, err)
+	}
+	byer, err := PostnummerRef{Nr: "7990"}.SupplerendeBynavne(nil)
+	if err != nil || len(byer) != 2 || !strings.HasPrefix(byer[0].Navn, "Lj") {
+		t.Fatalf("unexpected supplerende bynavne: %#v, %v", byer, err)
+	}
+	if _, err := (KommuneRef{Kode: "0999"}).Resolve(nil); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+	if _, err := (VejstykkeRef{Kode: "0001"}).Resolve(nil, "0101"); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
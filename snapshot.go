@@ -0,0 +1,65 @@
+package dawa
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// SenesteSekvensnummer returns the sekvensnummer of the latest hændelse in DAWA.
+//
+// See documentation at http://dawa.aws.dk/replikeringdok
+func SenesteSekvensnummer(ctx context.Context) (int64, error) {
+	q := query{host: DefaultHost, path: "/replikering/senestesekvensnummer"}
+	resp, err := q.RequestContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Close()
+	var res struct {
+		Sekvensnummer int64 `json:"sekvensnummer"`
+	}
+	err = json.NewDecoder(resp).Decode(&res)
+	if err != nil {
+		return 0, err
+	}
+	return res.Sekvensnummer, nil
+}
+
+// Snapshot will return all objects of the entity type as they were
+// at the given sekvensnummer ("udtræk").
+//
+// The objects are returned as hændelser with operation OperationInsert,
+// and sekvensnummer set to seq. Use SenesteSekvensnummer() to get the
+// current sekvensnummer, and use hændelser after seq to keep the data updated.
+// Example:
+//			seq, err := dawa.SenesteSekvensnummer(ctx)
+//			iter, err := dawa.Snapshot(ctx, dawa.EntityVejstykke, seq)
+//
+// See documentation at http://dawa.aws.dk/replikeringdok
+func Snapshot(ctx context.Context, entity Entity, seq int64) (*HændelseIter, error) {
+	q := query{host: DefaultHost, path: "/replikering/" + string(entity)}
+	q.add(&textQuery{Name: "sekvensnummer", Values: []string{strconv.FormatInt(seq, 10)}, Multi: false, Null: false})
+	q.add(&textQuery{Name: "noformat", Multi: false, Null: true})
+	resp, err := q.RequestContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := ImportSnapshotJSON(entity, seq, resp)
+	if err != nil {
+		resp.Close()
+		return nil, err
+	}
+	iter.AddCloser(resp)
+	return iter, nil
+}
+
+// ImportSnapshotJSON will import a snapshot ("udtræk") of the given entity type from a JSON input, supplied to the reader.
+// The input is a JSON array of objects in the replication format.
+//
+// An iterator will be returned that return all items as hændelser with
+// operation OperationInsert and the supplied sekvensnummer.
+func ImportSnapshotJSON(entity Entity, seq int64, in io.Reader) (*HændelseIter, error) {
+	return importHændelserJSON(entity, in, &hændelseInfo{Operation: OperationInsert, Sekvensnummer: seq})
+}
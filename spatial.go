@@ -0,0 +1,253 @@
+package dawa
+
+import (
+	"io"
+	"math"
+	"sort"
+)
+
+// SpatialIndex is an in-memory spatial index of adgangsadresser,
+// that allows reverse geocoding without contacting the server.
+//
+// Adgangsadresser are placed in a grid of 100 meter cells in ETRS89/UTM32,
+// like the 100m cells of Det Danske Kvadratnet, and all distances are in meters.
+//
+// Coordinates of adgangsadresser and query points may be given as
+// WGS84 [x,y] (længde, bredde), WGS84 [bredde, længde] as delivered by the CSV importers,
+// or ETRS89/UTM32 [øst, nord] as delivered by the replication API.
+// Since these ranges do not overlap in Denmark, the format is detected automatically.
+//
+// The index is not safe for concurrent modification, but
+// queries can be done concurrently when it is no longer modified.
+//
+// Use NewSpatialIndex() to get an initialized object.
+// Example:
+//			iter, err := dawa.ImportAdgangsAdresserCSV(file)
+//			si := dawa.NewSpatialIndex()
+//			err = si.Load(iter)
+//			res, ok := si.Nearest([]float64{12.5582, 55.6720})
+type SpatialIndex struct {
+	cellSize float64
+	cells    map[spatialCell][]spatialEntry
+	n        int
+
+	// Bounds of the cells in use.
+	minCell, maxCell spatialCell
+}
+
+// SpatialResult is an adgangsadresse found in a SpatialIndex.
+type SpatialResult struct {
+	AdgangsAdresse *AdgangsAdresse
+	Afstand        float64 // Distance to the query point in meters.
+}
+
+type spatialCell struct {
+	x, y int32
+}
+
+type spatialEntry struct {
+	x, y float64
+	a    *AdgangsAdresse
+}
+
+// NewSpatialIndex returns a new, empty SpatialIndex.
+func NewSpatialIndex() *SpatialIndex {
+	return &SpatialIndex{cellSize: 100, cells: make(map[spatialCell][]spatialEntry)}
+}
+
+// Load will add all adgangsadresser from the iterator to the index.
+// The iterator is read until io.EOF is returned.
+// Adgangsadresser without coordinates are skipped.
+func (s *SpatialIndex) Load(iter *AdgangsAdresseIter) error {
+	for {
+		a, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.Add(a)
+	}
+}
+
+// Add will add an adgangsadresse to the index.
+// If the adgangsadresse has no coordinates, it is not added and false is returned.
+func (s *SpatialIndex) Add(a *AdgangsAdresse) bool {
+	x, y, ok := spatialPoint(a.Adgangspunkt.Koordinater)
+	if !ok {
+		return false
+	}
+	c := s.cell(x, y)
+	if s.n == 0 {
+		s.minCell, s.maxCell = c, c
+	} else {
+		s.minCell.x, s.minCell.y = minInt32(s.minCell.x, c.x), minInt32(s.minCell.y, c.y)
+		s.maxCell.x, s.maxCell.y = maxInt32(s.maxCell.x, c.x), maxInt32(s.maxCell.y, c.y)
+	}
+	s.cells[c] = append(s.cells[c], spatialEntry{x: x, y: y, a: a})
+	s.n++
+	return true
+}
+
+// Len returns the number of adgangsadresser in the index.
+func (s *SpatialIndex) Len() int {
+	return s.n
+}
+
+// Nearest returns the adgangsadresse closest to the point.
+// If the index is empty, or the point is invalid, false is returned.
+func (s *SpatialIndex) Nearest(point []float64) (SpatialResult, bool) {
+	res := s.KNearest(point, 1)
+	if len(res) == 0 {
+		return SpatialResult{}, false
+	}
+	return res[0], true
+}
+
+// KNearest returns up to k adgangsadresser closest to the point,
+// ordered by distance.
+func (s *SpatialIndex) KNearest(point []float64, k int) []SpatialResult {
+	x, y, ok := spatialPoint(point)
+	if !ok || k <= 0 || s.n == 0 {
+		return nil
+	}
+	c := s.cell(x, y)
+	var res []SpatialResult
+	// Search rings of cells around the point, until no unsearched
+	// cell can contain a point closer than the k'th found.
+	// Rings that do not reach any cells in use are skipped.
+	r := maxInt32(maxInt32(s.minCell.x-c.x, c.x-s.maxCell.x), maxInt32(s.minCell.y-c.y, c.y-s.maxCell.y))
+	for r = maxInt32(r, 0); ; r++ {
+		s.ring(c, r, func(e []spatialEntry) {
+			for _, v := range e {
+				res = append(res, SpatialResult{AdgangsAdresse: v.a, Afstand: math.Hypot(v.x-x, v.y-y)})
+			}
+		})
+		if len(res) >= k {
+			sort.Sort(spatialByDistance(res))
+			res = res[:k]
+			if res[k-1].Afstand <= float64(r)*s.cellSize {
+				return res
+			}
+		}
+		if c.x-r <= s.minCell.x && c.y-r <= s.minCell.y && c.x+r >= s.maxCell.x && c.y+r >= s.maxCell.y {
+			// All cells have been searched.
+			sort.Sort(spatialByDistance(res))
+			return res
+		}
+	}
+}
+
+// WithinRadius returns all adgangsadresser within the radius in meters of the point,
+// ordered by distance.
+func (s *SpatialIndex) WithinRadius(point []float64, radius float64) []SpatialResult {
+	x, y, ok := spatialPoint(point)
+	if !ok || radius < 0 {
+		return nil
+	}
+	var res []SpatialResult
+	s.box(x-radius, y-radius, x+radius, y+radius, func(v spatialEntry) {
+		if d := math.Hypot(v.x-x, v.y-y); d <= radius {
+			res = append(res, SpatialResult{AdgangsAdresse: v.a, Afstand: d})
+		}
+	})
+	sort.Sort(spatialByDistance(res))
+	return res
+}
+
+// WithinPolygon returns all adgangsadresser inside the geometry.
+// The geometry may be in WGS84 or ETRS89/UTM32 coordinates,
+// for example the Geometri of a Jordstykke.
+func (s *SpatialIndex) WithinPolygon(m MultiPolygon) []*AdgangsAdresse {
+	minX, _, _, _ := m.Bounds()
+	if math.Abs(minX) < 1000 {
+		m = m.Transform(WGS84ToUTM32)
+	}
+	minX, minY, maxX, maxY := m.Bounds()
+	var res []*AdgangsAdresse
+	s.box(minX, minY, maxX, maxY, func(v spatialEntry) {
+		if m.Contains(v.x, v.y) {
+			res = append(res, v.a)
+		}
+	})
+	return res
+}
+
+// box calls fn with all entries in cells overlapping the box.
+func (s *SpatialIndex) box(minX, minY, maxX, maxY float64, fn func(v spatialEntry)) {
+	if s.n == 0 {
+		return
+	}
+	a, b := s.cell(minX, minY), s.cell(maxX, maxY)
+	a.x, a.y = maxInt32(a.x, s.minCell.x), maxInt32(a.y, s.minCell.y)
+	b.x, b.y = minInt32(b.x, s.maxCell.x), minInt32(b.y, s.maxCell.y)
+	for cx := a.x; cx <= b.x; cx++ {
+		for cy := a.y; cy <= b.y; cy++ {
+			for _, v := range s.cells[spatialCell{cx, cy}] {
+				if v.x >= minX && v.x <= maxX && v.y >= minY && v.y <= maxY {
+					fn(v)
+				}
+			}
+		}
+	}
+}
+
+// ring calls fn with the entries of all cells at distance r from c.
+func (s *SpatialIndex) ring(c spatialCell, r int32, fn func(e []spatialEntry)) {
+	if r == 0 {
+		fn(s.cells[c])
+		return
+	}
+	for i := -r; i <= r; i++ {
+		fn(s.cells[spatialCell{c.x + i, c.y - r}])
+		fn(s.cells[spatialCell{c.x + i, c.y + r}])
+	}
+	for i := -r + 1; i < r; i++ {
+		fn(s.cells[spatialCell{c.x - r, c.y + i}])
+		fn(s.cells[spatialCell{c.x + r, c.y + i}])
+	}
+}
+
+func (s *SpatialIndex) cell(x, y float64) spatialCell {
+	return spatialCell{x: int32(math.Floor(x / s.cellSize)), y: int32(math.Floor(y / s.cellSize))}
+}
+
+// spatialPoint returns the ETRS89/UTM32 coordinate of a point,
+// detecting the format as described on SpatialIndex.
+func spatialPoint(p []float64) (x, y float64, ok bool) {
+	if len(p) < 2 || (p[0] == 0 && p[1] == 0) {
+		return 0, 0, false
+	}
+	switch {
+	case math.Abs(p[0]) >= 1000:
+		// Already ETRS89/UTM32.
+		return p[0], p[1], true
+	case p[0] > p[1]:
+		// [bredde, længde]
+		x, y = WGS84ToUTM32(p[1], p[0])
+	default:
+		x, y = WGS84ToUTM32(p[0], p[1])
+	}
+	return x, y, true
+}
+
+type spatialByDistance []SpatialResult
+
+func (s spatialByDistance) Len() int           { return len(s) }
+func (s spatialByDistance) Less(i, j int) bool { return s[i].Afstand < s[j].Afstand }
+func (s spatialByDistance) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func minInt32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
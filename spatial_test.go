@@ -0,0 +1,98 @@
+package dawa
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUTM32(t *testing.T) {
+	// On the central meridian, øst is the false easting.
+	x, _ := WGS84ToUTM32(9, 56)
+	if math.Abs(x-500000) > 0.001 {
+		t.Fatalf("unexpected easting on central meridian: %v", x)
+	}
+	for _, p := range [][]float64{{12.5582, 55.6720}, {8.0, 54.6}, {15.2, 55.1}, {10.5, 57.7}} {
+		x, y := WGS84ToUTM32(p[0], p[1])
+		lon, lat := UTM32ToWGS84(x, y)
+		if math.Abs(lon-p[0]) > 1e-5 || math.Abs(lat-p[1]) > 1e-5 {
+			t.Fatalf("round trip of %v gave %v, %v", p, lon, lat)
+		}
+	}
+	// 0.001 degree of latitude is approximately 111 meters.
+	_, y1 := WGS84ToUTM32(12.5, 55.6)
+	_, y2 := WGS84ToUTM32(12.5, 55.601)
+	if d := y2 - y1; d < 110 || d > 112 {
+		t.Fatalf("unexpected distance: %v", d)
+	}
+}
+
+func TestSpatialIndex(t *testing.T) {
+	si := NewSpatialIndex()
+	base := []float64{12.5582, 55.6720}
+	bx, by := WGS84ToUTM32(base[0], base[1])
+	// Place addresses 0, 50, 150, 400 and 5000 meters east of the base point,
+	// using different coordinate formats.
+	dists := []float64{0, 50, 150, 400, 5000}
+	for i, d := range dists {
+		a := &AdgangsAdresse{ID: string(rune('a' + i))}
+		switch i % 3 {
+		case 0:
+			lon, lat := UTM32ToWGS84(bx+d, by)
+			a.Adgangspunkt.Koordinater = []float64{lon, lat}
+		case 1:
+			lon, lat := UTM32ToWGS84(bx+d, by)
+			a.Adgangspunkt.Koordinater = []float64{lat, lon}
+		case 2:
+			a.Adgangspunkt.Koordinater = []float64{bx + d, by}
+		}
+		if !si.Add(a) {
+			t.Fatalf("could not add %v", a.Adgangspunkt.Koordinater)
+		}
+	}
+	if si.Add(&AdgangsAdresse{}) {
+		t.Fatal("adgangsadresse without coordinates was added")
+	}
+	if si.Len() != len(dists) {
+		t.Fatalf("expected %d entries, got %d", len(dists), si.Len())
+	}
+
+	lon, lat := UTM32ToWGS84(bx+140, by)
+	res, ok := si.Nearest([]float64{lon, lat})
+	if !ok || res.AdgangsAdresse.ID != "c" || math.Abs(res.Afstand-10) > 0.01 {
+		t.Fatalf("unexpected nearest: %#v", res)
+	}
+
+	knn := si.KNearest(base, 3)
+	if len(knn) != 3 || knn[0].AdgangsAdresse.ID != "a" || knn[1].AdgangsAdresse.ID != "b" || knn[2].AdgangsAdresse.ID != "c" {
+		t.Fatalf("unexpected k nearest: %#v", knn)
+	}
+	if knn := si.KNearest(base, 10); len(knn) != len(dists) || knn[4].AdgangsAdresse.ID != "e" {
+		t.Fatalf("unexpected k nearest: %#v", knn)
+	}
+	// Point far away from all addresses.
+	if res, ok := si.Nearest([]float64{bx + 50000, by + 50000}); !ok || res.AdgangsAdresse.ID != "e" {
+		t.Fatalf("unexpected nearest: %#v", res)
+	}
+
+	within := si.WithinRadius(base, 200)
+	if len(within) != 3 || within[2].AdgangsAdresse.ID != "c" {
+		t.Fatalf("unexpected within radius: %#v", within)
+	}
+
+	// A square from 100 to 500 meters east, with a hole from 300 to 450 meters.
+	sq := func(x0, x1 float64) [][]float64 {
+		return [][]float64{{bx + x0, by - 10}, {bx + x1, by - 10}, {bx + x1, by + 10}, {bx + x0, by + 10}, {bx + x0, by - 10}}
+	}
+	poly := MultiPolygon{{sq(100, 500)}}
+	if in := si.WithinPolygon(poly); len(in) != 2 {
+		t.Fatalf("expected 2 in polygon, got %d", len(in))
+	}
+	poly = MultiPolygon{{sq(100, 500), sq(300, 450)}}
+	if in := si.WithinPolygon(poly); len(in) != 1 || in[0].ID != "c" {
+		t.Fatalf("unexpected in polygon with hole: %#v", in)
+	}
+	wgs := poly.Transform(UTM32ToWGS84)
+	if in := si.WithinPolygon(wgs); len(in) != 1 || in[0].ID != "c" {
+		t.Fatalf("unexpected in WGS84 polygon: %#v", in)
+	}
+}
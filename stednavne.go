@@ -0,0 +1,80 @@
+package dawa
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/ugorji/go/codec"
+)
+
+// Et stednavn er et navn på et sted, som ikke er en adresse, f.eks. en by, en bebyggelse,
+// en sø, et fredet område eller en seværdighed.
+// Stednavne stammer fra Danske Stednavne, og er inddelt i hovedtyper og undertyper.
+//
+// Stednavne er udstillet under /stednavne
+type Stednavn struct {
+	ID            string       `json:"id"`            // Stednavnets unikke id (UUID).
+	Href          string       `json:"href"`          // Stednavnets unikke URL.
+	Hovedtype     string       `json:"hovedtype"`     // Stednavnets hovedtype, f.eks. "Bebyggelse" eller "Vandløb".
+	Undertype     string       `json:"undertype"`     // Stednavnets undertype, f.eks. "by" eller "å".
+	Navn          string       `json:"navn"`          // Stednavnets navn.
+	Navnestatus   string       `json:"navnestatus"`   // Navnets status: "officielt", "suaut" eller "uofficielt".
+	Brugsnavn     string       `json:"brugsnavn"`     // Det navn, der anvendes i daglig tale, hvis det er forskelligt fra det officielle navn.
+	Kommuner      []KommuneRef `json:"kommuner"`      // Kommuner, som stednavnet er beliggende i.
+	VisueltCenter []float64    `json:"visueltcenter"` // Et punkt, som egner sig til visning af stednavnet på kort, som [x,y].
+	Bbox          []float64    `json:"bbox"`          // Stednavnets bounding box som [minx, miny, maxx, maxy].
+	ChangeInfo
+}
+
+// StednavnIter is an Iterator that enable you to get individual entries.
+type StednavnIter struct {
+	a   chan Stednavn
+	err error
+	validating
+	closer
+}
+
+// Next will return the next item.
+// It will return an error if that has been encountered.
+// When there are not more entries nil, io.EOF will be returned.
+func (a *StednavnIter) Next() (*Stednavn, error) {
+	for {
+		v, ok := <-a.a
+		if !ok {
+			return nil, a.err
+		}
+		if !a.skip(&v) {
+			return &v, nil
+		}
+	}
+}
+
+// ValidateTo will make the iterator validate each record with its Validate method.
+// Invalid records are sent to the sink and skipped, instead of aborting the import.
+// It must be called before the first call to Next.
+func (a *StednavnIter) ValidateTo(sink ErrorSink) *StednavnIter {
+	a.sink = sink
+	return a
+}
+
+// ImportStednavneJSON will import "stednavne" from a JSON input, supplied to the reader.
+// An iterator will be returned that return all items.
+func ImportStednavneJSON(in io.Reader) (*StednavnIter, error) {
+	var h codec.JsonHandle
+	h.DecodeOptions.ErrorIfNoField = JSONStrictFieldCheck
+	// use a buffered reader for efficiency
+	if _, ok := in.(io.ByteScanner); !ok {
+		in = bufio.NewReader(in)
+	}
+	ret := &StednavnIter{a: make(chan Stednavn, 100)}
+	go func() {
+		defer close(ret.a)
+		var dec *codec.Decoder = codec.NewDecoder(in, &h)
+		ret.err = dec.Decode(&ret.a)
+		if ret.err == nil {
+			ret.err = io.EOF
+		}
+	}()
+
+	return ret, nil
+}
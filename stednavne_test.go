@@ -0,0 +1,91 @@
+package dawa
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+var stednavne_json_input = `
+[
+{
+  "id": "12337669-a188-6b98-e053-d480220a5a3f",
+  "href": "http://dawa.aws.dk/stednavne/12337669-a188-6b98-e053-d480220a5a3f",
+  "hovedtype": "Bebyggelse",
+  "undertype": "by",
+  "navn": "Vråby",
+  "navnestatus": "officielt",
+  "brugsnavn": "",
+  "kommuner": [
+    {
+      "href": "http://dawa.aws.dk/kommuner/550",
+      "kode": "0550",
+      "navn": "Tønder"
+    }
+  ],
+  "visueltcenter": [8.5395, 55.0972],
+  "bbox": [8.535, 55.095, 8.545, 55.1],
+  "ændret": "2016-01-13T10:22:38.123Z",
+  "geo_ændret": "2016-01-13T10:22:38.123Z",
+  "geo_version": 1
+}
+]
+`
+
+func TestImportStednavneJSON(t *testing.T) {
+	var json_expect = []Stednavn{
+		Stednavn{
+			ID:            "12337669-a188-6b98-e053-d480220a5a3f",
+			Href:          "http://dawa.aws.dk/stednavne/12337669-a188-6b98-e053-d480220a5a3f",
+			Hovedtype:     "Bebyggelse",
+			Undertype:     "by",
+			Navn:          "Vråby",
+			Navnestatus:   "officielt",
+			Kommuner:      []KommuneRef{KommuneRef{Href: "http://dawa.aws.dk/kommuner/550", Kode: "0550", Navn: "Tønder"}},
+			VisueltCenter: []float64{8.5395, 55.0972},
+			Bbox:          []float64{8.535, 55.095, 8.545, 55.1},
+			ChangeInfo:    ChangeInfo{Ændret: "2016-01-13T10:22:38.123Z", GeoÆndret: "2016-01-13T10:22:38.123Z", GeoVersion: 1},
+		},
+	}
+
+	b := bytes.NewBuffer([]byte(stednavne_json_input))
+	iter, err := ImportStednavneJSON(b)
+	if err != nil {
+		t.Fatalf("ImportStednavneJSON: %v", err)
+	}
+	for _, expect := range json_expect {
+		item, err := iter.Next()
+		if err != nil {
+			t.Fatalf("ImportStednavneJSON, iter.Next(): %v", err)
+		}
+		if !reflect.DeepEqual(*item, expect) {
+			t.Fatalf("ImportStednavneJSON, value mismatch.\nGot:\n%#v\nExpected:\n%#v\n", *item, expect)
+		}
+	}
+	_, err = iter.Next()
+	if err != io.EOF {
+		t.Fatalf("ImportStednavneJSON: Expected io.EOF, got:%v", err)
+	}
+}
+
+var StednavnURL = []qb{
+	qb{NewStednavnQuery().URL(), DefaultHost + "/stednavne"},
+	qb{NewStednavnComplete().URL(), DefaultHost + "/stednavne/autocomplete"},
+
+	qb{NewStednavnQuery().Q(singleParam).URL(), DefaultHost + "/stednavne?q=" + singleEncoded + ""},
+	qb{NewStednavnQuery().Hovedtype(multiParam...).URL(), DefaultHost + "/stednavne?hovedtype=" + multiEncoded + ""},
+	qb{NewStednavnQuery().Undertype(multiParam...).URL(), DefaultHost + "/stednavne?undertype=" + multiEncoded + ""},
+	qb{NewStednavnQuery().Polygon(singleParam).URL(), DefaultHost + "/stednavne?polygon=" + singleEncoded + ""},
+	qb{NewStednavnQuery().Cirkel(singleParam).URL(), DefaultHost + "/stednavne?cirkel=" + singleEncoded + ""},
+	qb{NewStednavnQuery().Hovedtype("Bebyggelse").Undertype("by").Kommunekode("0550").URL(),
+		DefaultHost + "/stednavne?hovedtype=Bebyggelse&undertype=by&kommunekode=0550"},
+}
+
+func TestStednavnQueryURL(t *testing.T) {
+	for _, q := range StednavnURL {
+		if q.Got != q.Expected {
+			t.Fatalf("Unexpected value of parameter:\n     Was:\t%s\nExpected:\t%s", q.Got, q.Expected)
+		}
+	}
+}
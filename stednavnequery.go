@@ -0,0 +1,252 @@
+package dawa
+
+import (
+	"io"
+	"strconv"
+)
+
+// StednavnQuery is a new query for 'stednavn' objects for searching DAWA.
+// Use NewStednavnQuery() or NewStednavnComplete() to get an initialized object.
+// Example:
+//			// Search for towns named "Vråby"
+//			items, err := dawa.NewStednavnQuery().Hovedtype("Bebyggelse").Undertype("by").Navn("Vråby").All()
+//
+//			// If err is nil, we go a result
+//			if err == nil {
+//				fmt.Printf("Got items:%+v\n", items)
+//			}
+type StednavnQuery struct {
+	queryGeoJSON
+}
+
+// NewStednavnQuery returns a new query for 'stednavn' objects for searching DAWA.
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func NewStednavnQuery() *StednavnQuery {
+	return &StednavnQuery{queryGeoJSON: queryGeoJSON{query: query{host: DefaultHost, path: "/stednavne"}}}
+}
+
+// NewStednavnComplete returns a new autocomplete query for 'stednavn' objects for searching DAWA.
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func NewStednavnComplete() *StednavnQuery {
+	return &StednavnQuery{queryGeoJSON: queryGeoJSON{query: query{host: DefaultHost, path: "/stednavne/autocomplete"}}}
+}
+
+// GetStednavnID will return a single Stednavn with the specified ID.
+// Will return (nil, io.EOF) if there is no results.
+func GetStednavnID(id string) (*Stednavn, error) {
+	return NewStednavnQuery().ID(id).First()
+}
+
+// Iter will return an iterator that allows you to read the results
+// one by one.
+//
+// An example:
+//			iter, err := dawa.NewStednavnQuery().Q("Skagen").Iter()
+//			if err != nil {
+// 				panic(err)
+// 			}
+//
+//			for {
+//				a, err := iter.Next()
+//				if err == io.EOF {
+// 					iter.Close()
+//					break  // we are finished
+//				}
+//				if err != nil {
+//					panic(err)
+//				}
+// 				fmt.Printf("%+v\n", a)
+//			}
+//		}
+func (q StednavnQuery) Iter() (*StednavnIter, error) {
+	resp, err := q.NoFormat().Request()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := ImportStednavneJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+	iter.AddCloser(resp)
+	return iter, nil
+}
+
+// All returns all results as an array.
+func (q StednavnQuery) All() ([]Stednavn, error) {
+	resp, err := q.NoFormat().Request()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	ret := make([]Stednavn, 0)
+	iter, err := ImportStednavneJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		a, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if a != nil {
+			ret = append(ret, *a)
+		}
+	}
+	return ret, nil
+}
+
+// First will return the first result from a query.
+// Note the entire query is executed, so only use this if you expect a few results.
+//
+// Will return (nil, io.EOF) if there is no results.
+func (q StednavnQuery) First() (*Stednavn, error) {
+	resp, err := q.NoFormat().Request()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	iter, err := ImportStednavneJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := iter.Next()
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Q will add a parameter for 'q' to the StednavnQuery.
+//
+// Søgetekst. Der søges i stednavnets navn.
+// Alle ord i søgeteksten skal matche. Wildcard * er tilladt i slutningen af hvert ord.
+// Der skelnes ikke mellem store og små bogstaver.
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func (q *StednavnQuery) Q(s string) *StednavnQuery {
+	q.add(&textQuery{Name: "q", Values: []string{s}})
+	return q
+}
+
+// ID will add a parameter for 'id' to the StednavnQuery.
+//
+// Stednavnets unikke id. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func (q *StednavnQuery) ID(s ...string) *StednavnQuery {
+	q.add(&textQuery{Name: "id", Values: s, Multi: true})
+	return q
+}
+
+// Hovedtype will add a parameter for 'hovedtype' to the StednavnQuery.
+//
+// Stednavnets hovedtype, f.eks. "Bebyggelse". (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func (q *StednavnQuery) Hovedtype(s ...string) *StednavnQuery {
+	q.add(&textQuery{Name: "hovedtype", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Undertype will add a parameter for 'undertype' to the StednavnQuery.
+//
+// Stednavnets undertype, f.eks. "by". (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func (q *StednavnQuery) Undertype(s ...string) *StednavnQuery {
+	q.add(&textQuery{Name: "undertype", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Navn will add a parameter for 'navn' to the StednavnQuery.
+//
+// Stednavnets navn. Der skelnes mellem store og små bogstaver. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func (q *StednavnQuery) Navn(s ...string) *StednavnQuery {
+	q.add(&textQuery{Name: "navn", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Kommunekode will add a parameter for 'kommunekode' to the StednavnQuery.
+//
+// Find de stednavne, som ligger i kommunen angivet ved kommunekoden. 4 cifre. (Flerværdisøgning mulig).
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func (q *StednavnQuery) Kommunekode(s ...string) *StednavnQuery {
+	q.add(&textQuery{Name: "kommunekode", Values: s, Multi: true, Null: false})
+	return q
+}
+
+// Srid will add a parameter for 'srid' to the StednavnQuery.
+//
+// Angiver SRID for det koordinatsystem, som geospatiale parametre er angivet i. Default er 4326 (WGS84).
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func (q *StednavnQuery) Srid(s string) *StednavnQuery {
+	q.add(&textQuery{Name: "srid", Values: []string{s}, Multi: false, Null: false})
+	return q
+}
+
+// Polygon will add a parameter for 'polygon' to the StednavnQuery.
+//
+// Find de stednavne, som overlapper det angivne polygon.
+// Polygonet specificeres som et array af koordinater på samme måde som koordinaterne
+// specificeres i GeoJSON's polygon.
+// Bemærk at polygoner skal være lukkede, dvs. at første og sidste koordinat skal være identisk.
+// Eksempel: Polygon("[[[10.3,55.3],[10.4,55.3],[10.4,55.31],[10.4,55.31],[10.3,55.3]]]")
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func (q *StednavnQuery) Polygon(s string) *StednavnQuery {
+	q.add(&textQuery{Name: "polygon", Values: []string{s}, Multi: false, Null: false})
+	return q
+}
+
+// Cirkel will add a parameter for 'cirkel' to the StednavnQuery.
+//
+// Find de stednavne, som overlapper den cirkel angivet af koordinatet (x,y) og radius r.
+// Radius angives i meter. Cirkel("{x},{y},{r}")
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func (q *StednavnQuery) Cirkel(s string) *StednavnQuery {
+	q.add(&textQuery{Name: "cirkel", Values: []string{s}, Multi: false, Null: false})
+	return q
+}
+
+// Side will add a parameter for 'side' to the StednavnQuery.
+//
+// Angiver hvilken siden som skal leveres. Se Paginering.
+// http://dawa.aws.dk/generelt#paginering
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func (q *StednavnQuery) Side(i int) *StednavnQuery {
+	q.add(&textQuery{Name: "side", Values: []string{strconv.Itoa(i)}, Multi: false, Null: true})
+	return q
+}
+
+// PerSide will add a parameter for 'per_side' to the StednavnQuery.
+//
+// Antal resultater per side. Se Paginering.
+// http://dawa.aws.dk/generelt#paginering
+//
+// See documentation at http://dawa.aws.dk/stednavnedok
+func (q *StednavnQuery) PerSide(i int) *StednavnQuery {
+	q.add(&textQuery{Name: "per_side", Values: []string{strconv.Itoa(i)}, Multi: false, Null: true})
+	return q
+}
+
+// NoFormat will disable extra whitespace. Always enabled when querying
+func (q *StednavnQuery) NoFormat() *StednavnQuery {
+	q.add(&textQuery{Name: "noformat", Multi: false, Null: true})
+	return q
+}
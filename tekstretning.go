@@ -0,0 +1,102 @@
+package dawa
+
+import (
+	"math"
+)
+
+// Tekstretning is the direction of the text of an adresse in gon,
+// where the full circle is 400 gon and 200 is horizontal. Values are 0.00-400.00.
+//
+// The angle is converted to a rotation of the label counter-clockwise from horizontal,
+// so 200 gives 0 degrees, 300 gives 90 degrees (reading upwards) and 100 gives -90 degrees (reading downwards).
+//
+// Example placing a husnummer label on a map, that is rotated 30 degrees counter-clockwise:
+//			r := a.Adgangspunkt.Tekstretning.LabelRotation(30)
+//			// Screen coordinates with y pointing down rotate clockwise, so negate the angle.
+//			ctx.Rotate(-r * math.Pi / 180)
+type Tekstretning float64
+
+// TekstretningVandret is the direction of horizontal text.
+const TekstretningVandret Tekstretning = 200
+
+// TekstretningFromGrader returns the Tekstretning of an angle in degrees,
+// where the full circle is 360 degrees. The result is in the range 0-400.
+func TekstretningFromGrader(grader float64) Tekstretning {
+	return Tekstretning(gonNormalize(grader / 0.9))
+}
+
+// TekstretningFromRadianer returns the Tekstretning of an angle in radians,
+// where the full circle is 2*Pi. The result is in the range 0-400.
+func TekstretningFromRadianer(radianer float64) Tekstretning {
+	return Tekstretning(gonNormalize(radianer * 200 / math.Pi))
+}
+
+// Valid returns true if the value is in the range 0-400.
+func (t Tekstretning) Valid() bool {
+	return t >= 0 && t <= 400
+}
+
+// Gon returns the angle in gon.
+func (t Tekstretning) Gon() float64 {
+	return float64(t)
+}
+
+// Grader returns the angle in degrees, so 200 gon is 180 degrees.
+func (t Tekstretning) Grader() float64 {
+	return float64(t) * 0.9
+}
+
+// Radianer returns the angle in radians, so 200 gon is Pi.
+func (t Tekstretning) Radianer() float64 {
+	return float64(t) * math.Pi / 200
+}
+
+// Rotation returns the rotation of the text in degrees counter-clockwise from horizontal,
+// in the range (-180, 180]. Horizontal text (200 gon) has a rotation of 0.
+func (t Tekstretning) Rotation() float64 {
+	return rotationNormalize(t.Grader() - 180)
+}
+
+// RotationRadianer returns the rotation of the text in radians counter-clockwise from horizontal,
+// in the range (-Pi, Pi].
+func (t Tekstretning) RotationRadianer() float64 {
+	return t.Rotation() * math.Pi / 180
+}
+
+// LabelRotation returns the rotation of a label in degrees counter-clockwise from horizontal,
+// on a map that is rotated kortRotation degrees counter-clockwise.
+// Use 0 for a map with north up.
+//
+// The label is kept readable, so the result is in the range (-90, 90].
+// A label that would be upside down is turned 180 degrees, which keeps it along the same line.
+func (t Tekstretning) LabelRotation(kortRotation float64) float64 {
+	r := rotationNormalize(t.Rotation() + kortRotation)
+	switch {
+	case r > 90:
+		r -= 180
+	case r <= -90:
+		r += 180
+	}
+	return r
+}
+
+// gonNormalize returns the angle in gon normalized to the range [0, 400).
+func gonNormalize(v float64) float64 {
+	v = math.Mod(v, 400)
+	if v < 0 {
+		v += 400
+	}
+	return v
+}
+
+// rotationNormalize returns the angle in degrees normalized to the range (-180, 180].
+func rotationNormalize(v float64) float64 {
+	v = math.Mod(v, 360)
+	switch {
+	case v > 180:
+		v -= 360
+	case v <= -180:
+		v += 360
+	}
+	return v
+}
@@ -0,0 +1,37 @@
+package dawa
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTekstretning(t *testing.T) {
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	if !near(TekstretningVandret.Grader(), 180) || !near(TekstretningVandret.Radianer(), math.Pi) || TekstretningVandret.Rotation() != 0 {
+		t.Fatal("unexpected conversion of horizontal text")
+	}
+	rotations := map[Tekstretning]float64{0: 180, 100: -90, 200: 0, 300: 90, 400: 180, 250: 45, 128.34: -64.494}
+	for in, want := range rotations {
+		if got := in.Rotation(); !near(got, want) {
+			t.Errorf("%v: got rotation %v, want %v", in, got, want)
+		}
+	}
+	labels := map[Tekstretning]float64{0: 0, 100: 90, 300: 90, 250: 45, 150: -45, 350: -45}
+	for in, want := range labels {
+		if got := in.LabelRotation(0); !near(got, want) {
+			t.Errorf("%v: got label rotation %v, want %v", in, got, want)
+		}
+	}
+	if got := Tekstretning(200).LabelRotation(120); !near(got, -60) {
+		t.Fatalf("unexpected label rotation on rotated map: %v", got)
+	}
+	if got := TekstretningFromGrader(-90); !near(float64(got), 300) {
+		t.Fatalf("unexpected tekstretning %v", got)
+	}
+	if got := TekstretningFromRadianer(math.Pi / 2); !near(float64(got), 100) {
+		t.Fatalf("unexpected tekstretning %v", got)
+	}
+	if Tekstretning(401).Valid() || !Tekstretning(400).Valid() {
+		t.Fatal("unexpected Valid() result")
+	}
+}
@@ -0,0 +1,71 @@
+package dawa
+
+import (
+	"math"
+)
+
+// Constants for the GRS80/WGS84 ellipsoid and UTM zone 32.
+const (
+	utmA      = 6378137.0
+	utmF      = 1 / 298.257223563
+	utmK0     = 0.9996
+	utmLon0   = 9.0 // Central meridian of zone 32.
+	utmFalseE = 500000.0
+)
+
+var (
+	utmE2  = utmF * (2 - utmF)
+	utmEP2 = utmE2 / (1 - utmE2)
+)
+
+// WGS84ToUTM32 converts a WGS84 coordinate to ETRS89/UTM zone 32N (EPSG:25832),
+// which is used by DAWA for "etrs89koordinat" fields and by Det Danske Kvadratnet.
+//
+// ETRS89 and WGS84 are treated as identical, which is accurate to within
+// a meter in Denmark.
+func WGS84ToUTM32(longitude, latitude float64) (øst, nord float64) {
+	phi := latitude * math.Pi / 180
+	lambda := (longitude - utmLon0) * math.Pi / 180
+	e2, e4, e6 := utmE2, utmE2*utmE2, utmE2*utmE2*utmE2
+
+	sin, cos, tan := math.Sin(phi), math.Cos(phi), math.Tan(phi)
+	n := utmA / math.Sqrt(1-e2*sin*sin)
+	t := tan * tan
+	c := utmEP2 * cos * cos
+	a := cos * lambda
+	m := utmA * ((1-e2/4-3*e4/64-5*e6/256)*phi -
+		(3*e2/8+3*e4/32+45*e6/1024)*math.Sin(2*phi) +
+		(15*e4/256+45*e6/1024)*math.Sin(4*phi) -
+		(35*e6/3072)*math.Sin(6*phi))
+
+	a2 := a * a
+	øst = utmFalseE + utmK0*n*(a+(1-t+c)*a2*a/6+(5-18*t+t*t+72*c-58*utmEP2)*a2*a2*a/120)
+	nord = utmK0 * (m + n*tan*(a2/2+(5-t+9*c+4*c*c)*a2*a2/24+(61-58*t+t*t+600*c-330*utmEP2)*a2*a2*a2/720))
+	return øst, nord
+}
+
+// UTM32ToWGS84 converts an ETRS89/UTM zone 32N coordinate to WGS84.
+// It is the inverse of WGS84ToUTM32.
+func UTM32ToWGS84(øst, nord float64) (longitude, latitude float64) {
+	e2, e4, e6 := utmE2, utmE2*utmE2, utmE2*utmE2*utmE2
+	m := nord / utmK0
+	mu := m / (utmA * (1 - e2/4 - 3*e4/64 - 5*e6/256))
+	e1 := (1 - math.Sqrt(1-e2)) / (1 + math.Sqrt(1-e2))
+	phi1 := mu + (3*e1/2-27*e1*e1*e1/32)*math.Sin(2*mu) +
+		(21*e1*e1/16-55*e1*e1*e1*e1/32)*math.Sin(4*mu) +
+		(151*e1*e1*e1/96)*math.Sin(6*mu) +
+		(1097*e1*e1*e1*e1/512)*math.Sin(8*mu)
+
+	sin, cos, tan := math.Sin(phi1), math.Cos(phi1), math.Tan(phi1)
+	c1 := utmEP2 * cos * cos
+	t1 := tan * tan
+	n1 := utmA / math.Sqrt(1-e2*sin*sin)
+	r1 := utmA * (1 - e2) / math.Pow(1-e2*sin*sin, 1.5)
+	d := (øst - utmFalseE) / (n1 * utmK0)
+	d2 := d * d
+
+	phi := phi1 - (n1*tan/r1)*(d2/2-(5+3*t1+10*c1-4*c1*c1-9*utmEP2)*d2*d2/24+
+		(61+90*t1+298*c1+45*t1*t1-252*utmEP2-3*c1*c1)*d2*d2*d2/720)
+	lambda := (d - (1+2*t1+c1)*d2*d/6 + (5-2*c1+28*t1-3*c1*c1+8*utmEP2+24*t1*t1)*d2*d2*d/120) / cos
+	return utmLon0 + lambda*180/math.Pi, phi * 180 / math.Pi
+}
@@ -0,0 +1,592 @@
+package dawa
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldError is a violation of the rules of a single field, found by Validate.
+type FieldError struct {
+	Field   string // The name of the field, like "Adgangsadresse.Postnummer.Nr".
+	Value   string // The invalid value.
+	Message string // Description of the rule that was violated.
+}
+
+// ValidationError is returned by the Validate methods,
+// and contains all field violations of the record.
+type ValidationError []FieldError
+
+// Error returns all violations separated by "; ".
+func (v ValidationError) Error() string {
+	s := make([]string, len(v))
+	for i, f := range v {
+		s[i] = fmt.Sprintf("%s '%s': %s", f.Field, f.Value, f.Message)
+	}
+	return "invalid fields: " + strings.Join(s, "; ")
+}
+
+// ErrorSink receives records that failed validation during import.
+// The record is a pointer to the imported object, like *Adresse,
+// and err is the ValidationError returned by its Validate method.
+type ErrorSink func(record interface{}, err error)
+
+// validating is embedded in the import iterators,
+// and holds the sink set by ValidateTo.
+type validating struct {
+	sink ErrorSink
+}
+
+// skip returns true if record failed validation and was sent to the sink.
+// record is a pointer to the imported object.
+// Records are never skipped if no sink is set, or they have no Validate method.
+func (v *validating) skip(record interface{}) bool {
+	if v.sink == nil {
+		return false
+	}
+	val, ok := record.(interface {
+		Validate() error
+	})
+	if !ok {
+		return false
+	}
+	if err := val.Validate(); err != nil {
+		v.sink(record, err)
+		return true
+	}
+	return false
+}
+
+// validator collects field violations.
+type validator struct {
+	errs ValidationError
+}
+
+func (v *validator) add(field, value, msg string) {
+	v.errs = append(v.errs, FieldError{Field: field, Value: value, Message: msg})
+}
+
+// digits checks that value has exactly n digits.
+func (v *validator) digits(field, value string, n int) {
+	if len(value) != n || !validDigits(value) {
+		v.add(field, value, fmt.Sprintf("must be %d digits", n))
+	}
+}
+
+// maxDigits checks that value has up to n digits. An empty value is allowed.
+func (v *validator) maxDigits(field, value string, n int) {
+	if value != "" && (len(value) > n || !validDigits(value)) {
+		v.add(field, value, fmt.Sprintf("must be up to %d digits", n))
+	}
+}
+
+// maxLen checks that value has at most n characters.
+func (v *validator) maxLen(field, value string, n int) {
+	if utf8.RuneCountInString(value) > n {
+		v.add(field, value, fmt.Sprintf("must be at most %d characters", n))
+	}
+}
+
+// uuid checks that value is a well-formed UUID.
+func (v *validator) uuid(field, value string) {
+	if !validUUID(value) {
+		v.add(field, value, "must be a UUID")
+	}
+}
+
+// required checks that value is set.
+func (v *validator) required(field, value string) {
+	if value == "" {
+		v.add(field, value, "must be set")
+	}
+}
+
+// intRange checks that value is between min and max, both included.
+func (v *validator) intRange(field string, value, min, max int) {
+	if value < min || value > max {
+		v.add(field, strconv.Itoa(value), fmt.Sprintf("must be %d-%d", min, max))
+	}
+}
+
+// check adds an error if ok is false.
+func (v *validator) check(ok bool, field, value, msg string) {
+	if !ok {
+		v.add(field, value, msg)
+	}
+}
+
+// nested adds the violations of a nested object, with the field names prefixed.
+// An empty prefix adds the violations of an embedded object unchanged.
+func (v *validator) nested(prefix string, err error) {
+	if ve, ok := err.(ValidationError); ok {
+		for _, f := range ve {
+			if prefix != "" {
+				f.Field = prefix + "." + f.Field
+			}
+			v.errs = append(v.errs, f)
+		}
+	} else if err != nil {
+		v.add(prefix, "", err.Error())
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+func validDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// validUUID returns true if s is a UUID like "0a3f50a0-75eb-32b8-e044-0003ba298018".
+func validUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Validate checks that Kode is 4 digits.
+// A ValidationError with all violations is returned.
+func (k KommuneRef) Validate() error {
+	var v validator
+	v.digits("Kode", k.Kode, 4)
+	return v.err()
+}
+
+// Validate checks that Kode is 4 digits, and Regionskode if set.
+// A ValidationError with all violations is returned.
+func (k Kommune) Validate() error {
+	var v validator
+	v.digits("Kode", k.Kode, 4)
+	if k.Regionskode != "" {
+		v.digits("Regionskode", k.Regionskode, 4)
+	}
+	return v.err()
+}
+
+// Validate checks that Nr is 4 digits.
+// A ValidationError with all violations is returned.
+func (p PostnummerRef) Validate() error {
+	var v validator
+	v.digits("Nr", p.Nr, 4)
+	return v.err()
+}
+
+// Validate checks that Nr is 4 digits, and the kommuner and stormodtageradresser.
+// A ValidationError with all violations is returned.
+func (p Postnummer) Validate() error {
+	var v validator
+	v.digits("Nr", p.Nr, 4)
+	v.maxLen("Navn", p.Navn, 20)
+	for i, k := range p.Kommuner {
+		v.nested(fmt.Sprintf("Kommuner[%d]", i), k.Validate())
+	}
+	for i, a := range p.Stormodtageradresser {
+		v.nested(fmt.Sprintf("Stormodtageradresser[%d]", i), a.Validate())
+	}
+	return v.err()
+}
+
+// Validate checks that Kode is 4 digits.
+// A ValidationError with all violations is returned.
+func (r VejstykkeRef) Validate() error {
+	var v validator
+	v.digits("Kode", r.Kode, 4)
+	return v.err()
+}
+
+// Validate checks that Kode is 4 digits, the length of the names, the kommune and the postnumre.
+// A ValidationError with all violations is returned.
+func (s Vejstykke) Validate() error {
+	var v validator
+	v.digits("Kode", s.Kode, 4)
+	v.maxLen("Navn", s.Navn, 40)
+	v.maxLen("Adresseringsnavn", s.Adresseringsnavn, 20)
+	v.nested("Kommune", s.Kommune.Validate())
+	for i, p := range s.Postnumre {
+		v.nested(fmt.Sprintf("Postnumre[%d]", i), p.Validate())
+	}
+	return v.err()
+}
+
+// Validate checks that ID is a UUID.
+// A ValidationError with all violations is returned.
+func (r AdgangsAdresseRef) Validate() error {
+	var v validator
+	v.uuid("ID", r.ID)
+	return v.err()
+}
+
+// Validate checks the fields of the adgangsadresse.
+// ID must be a UUID, kommunekode, vejkode and postnr must be 4 digits,
+// EsrEjendomsNr up to 7 digits and the husnr must be valid.
+// Codes, areas, etage, dør, DDKN and Kvh are checked if set.
+// A ValidationError with all violations is returned.
+func (a AdgangsAdresse) Validate() error {
+	var v validator
+	v.uuid("ID", a.ID)
+	v.nested("Kommune", a.Kommune.Validate())
+	v.nested("Vejstykke", a.Vejstykke.Validate())
+	v.nested("Postnummer", a.Postnummer.Validate())
+	v.check(a.Husnr.Valid(), "Husnr", string(a.Husnr), "must be 1-999 optionally followed by a letter A-Z")
+	v.maxDigits("EsrEjendomsNr", a.EsrEjendomsNr, 7)
+	v.maxLen("SupplerendeBynavn", a.SupplerendeBynavn, 34)
+	areas := []struct {
+		field string
+		key   string
+		err   func() error
+	}{
+		{"Region", a.Region.Kode, a.Region.Validate},
+		{"Sogn", a.Sogn.Kode, a.Sogn.Validate},
+		{"Politikreds", a.Politikreds.Kode, a.Politikreds.Validate},
+		{"Retskreds", a.Retskreds.Kode, a.Retskreds.Validate},
+		{"Opstillingskreds", a.Opstillingskreds.Kode, a.Opstillingskreds.Validate},
+		{"Storkreds", a.Storkreds.Nummer, a.Storkreds.Validate},
+		{"Valglandsdel", a.Valglandsdel.Bogstav, a.Valglandsdel.Validate},
+		{"Landsdel", a.Landsdel.Nuts3, a.Landsdel.Validate},
+		{"Afstemningsområde", a.Afstemningsområde.Nummer, a.Afstemningsområde.Validate},
+		{"Menighedsrådsafstemningsområde", a.Menighedsrådsafstemningsområde.Nummer, a.Menighedsrådsafstemningsområde.Validate},
+	}
+	for _, o := range areas {
+		if o.key != "" {
+			v.nested(o.field, o.err())
+		}
+	}
+	v.check(a.Status == 0 || a.Status.Valid(), "Status", strconv.Itoa(int(a.Status)), "unknown status")
+	v.check(a.Zone == "" || a.Zone.Valid(), "Zone", string(a.Zone), "unknown zone")
+	v.check(a.Etage == "" || a.Etage.Valid(), "Etage", string(a.Etage), "must be 1-99, st, kl or kl2-kl9")
+	v.check(a.Door == "" || a.Door.Valid(), "Door", string(a.Door), "must be 1-9999 or up to 4 letters, / and -")
+
+	p := a.Adgangspunkt
+	v.check(p.Kilde == 0 || p.Kilde.Valid(), "Adgangspunkt.Kilde", strconv.Itoa(int(p.Kilde)), "unknown kilde")
+	v.check(p.Nøjagtighed == "" || p.Nøjagtighed.Valid(), "Adgangspunkt.Nøjagtighed", string(p.Nøjagtighed), "unknown nøjagtighed")
+	v.check(p.Tekniskstandard == "" || p.Tekniskstandard.Valid(), "Adgangspunkt.Tekniskstandard", string(p.Tekniskstandard), "unknown tekniskstandard")
+	v.check(p.Tekstretning.Valid(), "Adgangspunkt.Tekstretning", strconv.FormatFloat(float64(p.Tekstretning), 'f', -1, 64), "must be 0-400")
+
+	for _, d := range []struct{ field, value string }{{"DDKN.M100", a.DDKN.M100}, {"DDKN.Km1", a.DDKN.Km1}, {"DDKN.Km10", a.DDKN.Km10}} {
+		if _, err := ParseDDKNCelle(d.value); d.value != "" && err != nil {
+			v.add(d.field, d.value, err.Error())
+		}
+	}
+	if a.Kvh != "" {
+		if err := a.CheckKVH(); err != nil {
+			v.add("Kvh", a.Kvh, err.Error())
+		}
+	}
+	return v.err()
+}
+
+// Validate checks the fields of the adresse and its adgangsadresse.
+// ID must be a UUID, and etage, dør, status and Kvhx are checked if set.
+// See AdgangsAdresse.Validate for the rules of the adgangsadresse.
+// A ValidationError with all violations is returned.
+func (a Adresse) Validate() error {
+	var v validator
+	v.uuid("ID", a.ID)
+	v.nested("Adgangsadresse", a.Adgangsadresse.Validate())
+	v.check(a.Status == 0 || a.Status.Valid(), "Status", strconv.Itoa(int(a.Status)), "unknown status")
+	v.check(a.Etage == "" || a.Etage.Valid(), "Etage", string(a.Etage), "must be 1-99, st, kl or kl2-kl9")
+	v.check(a.Dør == "" || a.Dør.Valid(), "Dør", string(a.Dør), "must be 1-9999 or up to 4 letters, / and -")
+	if a.Kvhx != "" {
+		if err := a.CheckKVHX(); err != nil {
+			v.add("Kvhx", a.Kvhx, err.Error())
+		}
+	}
+	return v.err()
+}
+
+// Validate checks that Navn is set and at most 34 characters, and the kommuner and postnumre.
+// A ValidationError with all violations is returned.
+func (s SupplBynavn) Validate() error {
+	var v validator
+	v.required("Navn", s.Navn)
+	v.maxLen("Navn", s.Navn, 34)
+	for i, k := range s.Kommuner {
+		v.nested(fmt.Sprintf("Kommuner[%d]", i), k.Validate())
+	}
+	for i, p := range s.Postnumre {
+		v.nested(fmt.Sprintf("Postnumre[%d]", i), p.Validate())
+	}
+	return v.err()
+}
+
+// Validate checks that the ID is set, and the adgangsadresser and kommuner.
+// A ValidationError with all violations is returned.
+func (b Bygning) Validate() error {
+	var v validator
+	v.required("ID", b.ID)
+	for i, a := range b.Adgangsadresser {
+		v.nested(fmt.Sprintf("Adgangsadresser[%d]", i), a.Validate())
+	}
+	for i, k := range b.Kommuner {
+		v.nested(fmt.Sprintf("Kommuner[%d]", i), k.Validate())
+	}
+	return v.err()
+}
+
+// Validate checks that Matrikelnr is set, EsrEjendomsNr is up to 7 digits,
+// UdvidetEsrEjendomsNr up to 10 digits, and the kommunekode if set.
+// A ValidationError with all violations is returned.
+func (j Jordstykke) Validate() error {
+	var v validator
+	v.required("Matrikelnr", j.Matrikelnr)
+	v.maxDigits("EsrEjendomsNr", j.EsrEjendomsNr, 7)
+	v.maxDigits("UdvidetEsrEjendomsNr", j.UdvidetEsrEjendomsNr, 10)
+	if j.Kommune.Kode != "" {
+		v.nested("Kommune", j.Kommune.Validate())
+	}
+	return v.err()
+}
+
+// Validate checks that ID is a UUID, Navn is set, and the kommuner.
+// A ValidationError with all violations is returned.
+func (s Stednavn) Validate() error {
+	var v validator
+	v.uuid("ID", s.ID)
+	v.required("Navn", s.Navn)
+	for i, k := range s.Kommuner {
+		v.nested(fmt.Sprintf("Kommuner[%d]", i), k.Validate())
+	}
+	return v.err()
+}
+
+// Validate checks that Kode is set.
+// A ValidationError with all violations is returned.
+func (e Ejerlav) Validate() error {
+	var v validator
+	v.intRange("Kode", e.Kode, 1, 9999999)
+	return v.err()
+}
+
+// Validate checks that Kode is 4 digits.
+// It is also used by Region.
+// A ValidationError with all violations is returned.
+func (r RegionRef) Validate() error {
+	var v validator
+	v.digits("Kode", r.Kode, 4)
+	return v.err()
+}
+
+// Validate checks that Kode is 4 digits.
+// It is also used by Sogn.
+// A ValidationError with all violations is returned.
+func (s SognRef) Validate() error {
+	var v validator
+	v.digits("Kode", s.Kode, 4)
+	return v.err()
+}
+
+// Validate checks that Kode is 4 digits.
+// It is also used by Politikreds.
+// A ValidationError with all violations is returned.
+func (p PolitikredsRef) Validate() error {
+	var v validator
+	v.digits("Kode", p.Kode, 4)
+	return v.err()
+}
+
+// Validate checks that Kode is 4 digits.
+// It is also used by Retskreds.
+// A ValidationError with all violations is returned.
+func (r RetskredsRef) Validate() error {
+	var v validator
+	v.digits("Kode", r.Kode, 4)
+	return v.err()
+}
+
+// Validate checks that Kode is set and up to 4 digits.
+// It is also used by Opstillingskreds.
+// A ValidationError with all violations is returned.
+func (o OpstillingskredsRef) Validate() error {
+	var v validator
+	v.required("Kode", o.Kode)
+	v.maxDigits("Kode", o.Kode, 4)
+	return v.err()
+}
+
+// Validate checks that Bogstav is a single letter A-Z.
+// It is also used by Valglandsdel.
+// A ValidationError with all violations is returned.
+func (r ValglandsdelRef) Validate() error {
+	var v validator
+	v.check(len(r.Bogstav) == 1 && r.Bogstav[0] >= 'A' && r.Bogstav[0] <= 'Z', "Bogstav", r.Bogstav, "must be a letter A-Z")
+	return v.err()
+}
+
+// Validate checks that Nummer is set and up to 2 digits.
+// A ValidationError with all violations is returned.
+func (r StorkredsRef) Validate() error {
+	var v validator
+	v.required("Nummer", r.Nummer)
+	v.maxDigits("Nummer", r.Nummer, 2)
+	return v.err()
+}
+
+// Validate checks that Nummer is set and up to 2 digits,
+// and the regionskode and valglandsdel if set.
+// A ValidationError with all violations is returned.
+func (s Storkreds) Validate() error {
+	var v validator
+	v.nested("", s.StorkredsRef.Validate())
+	if s.Regionskode != "" {
+		v.digits("Regionskode", s.Regionskode, 4)
+	}
+	if s.Valglandsdel.Bogstav != "" {
+		v.nested("Valglandsdel", s.Valglandsdel.Validate())
+	}
+	return v.err()
+}
+
+// Validate checks that Nuts3 is a NUTS 3 code, like "DK011".
+// It is also used by Landsdel.
+// A ValidationError with all violations is returned.
+func (r LandsdelRef) Validate() error {
+	var v validator
+	v.check(len(r.Nuts3) == 5 && strings.HasPrefix(r.Nuts3, "DK") && validDigits(r.Nuts3[2:]), "Nuts3", r.Nuts3, "must be DK followed by 3 digits")
+	return v.err()
+}
+
+// Validate checks that Nummer is set and up to 3 digits.
+// A ValidationError with all violations is returned.
+func (r AfstemningsområdeRef) Validate() error {
+	var v validator
+	v.required("Nummer", r.Nummer)
+	v.maxDigits("Nummer", r.Nummer, 3)
+	return v.err()
+}
+
+// Validate checks that Nummer is set and up to 3 digits,
+// and the kommune, opstillingskreds and afstemningssted if set.
+// A ValidationError with all violations is returned.
+func (a Afstemningsområde) Validate() error {
+	var v validator
+	v.nested("", a.AfstemningsområdeRef.Validate())
+	if a.Kommune.Kode != "" {
+		v.nested("Kommune", a.Kommune.Validate())
+	}
+	if a.Opstillingskreds.Kode != "" {
+		v.nested("Opstillingskreds", a.Opstillingskreds.Validate())
+	}
+	if a.Afstemningssted.Adgangsadresse.ID != "" {
+		v.nested("Afstemningssted.Adgangsadresse", a.Afstemningssted.Adgangsadresse.Validate())
+	}
+	return v.err()
+}
+
+// Validate checks that Nummer is set and up to 3 digits.
+// A ValidationError with all violations is returned.
+func (r MenighedsrådsafstemningsområdeRef) Validate() error {
+	var v validator
+	v.required("Nummer", r.Nummer)
+	v.maxDigits("Nummer", r.Nummer, 3)
+	return v.err()
+}
+
+// Validate checks that Nummer is set and up to 3 digits,
+// and the kommune and sogn if set.
+// A ValidationError with all violations is returned.
+func (m Menighedsrådsafstemningsområde) Validate() error {
+	var v validator
+	v.nested("", m.MenighedsrådsafstemningsområdeRef.Validate())
+	if m.Kommune.Kode != "" {
+		v.nested("Kommune", m.Kommune.Validate())
+	}
+	if m.Sogn.Kode != "" {
+		v.nested("Sogn", m.Sogn.Validate())
+	}
+	return v.err()
+}
+
+// Validate checks that the IDs are UUIDs, and the status, kilde, etage and dør.
+// A ValidationError with all violations is returned.
+func (r ReplikeringAdresse) Validate() error {
+	var v validator
+	v.uuid("ID", r.ID)
+	v.uuid("AdgangsadresseID", r.AdgangsadresseID)
+	v.check(r.Status == 0 || r.Status.Valid(), "Status", strconv.Itoa(int(r.Status)), "unknown status")
+	v.check(r.Kilde == 0 || r.Kilde.Valid(), "Kilde", strconv.Itoa(int(r.Kilde)), "unknown kilde")
+	v.check(r.Etage == "" || r.Etage.Valid(), "Etage", string(r.Etage), "must be 1-99, st, kl or kl2-kl9")
+	v.check(r.Dør == "" || r.Dør.Valid(), "Dør", string(r.Dør), "must be 1-9999 or up to 4 letters, / and -")
+	return v.err()
+}
+
+// Validate checks that ID is a UUID, the kommunekode, vejkode and postnr are 1-9999,
+// EsrEjendomsNr is up to 7 digits, the husnr is valid, and the codes if set.
+// A ValidationError with all violations is returned.
+func (r ReplikeringAdgangsAdresse) Validate() error {
+	var v validator
+	v.uuid("ID", r.ID)
+	v.intRange("Kommunekode", r.Kommunekode, 1, 9999)
+	v.intRange("Vejkode", r.Vejkode, 1, 9999)
+	v.intRange("Postnr", r.Postnr, 1, 9999)
+	v.check(r.Husnr.Valid(), "Husnr", string(r.Husnr), "must be 1-999 optionally followed by a letter A-Z")
+	v.intRange("EsrEjendomsNr", r.EsrEjendomsNr, 0, 9999999)
+	v.maxLen("SupplerendeBynavn", r.SupplerendeBynavn, 34)
+	v.check(r.Status == 0 || r.Status.Valid(), "Status", strconv.Itoa(int(r.Status)), "unknown status")
+	v.check(r.Kilde == 0 || r.Kilde.Valid(), "Kilde", strconv.Itoa(int(r.Kilde)), "unknown kilde")
+	v.check(r.Nøjagtighed == "" || r.Nøjagtighed.Valid(), "Nøjagtighed", string(r.Nøjagtighed), "unknown nøjagtighed")
+	v.check(r.Tekniskstandard == "" || r.Tekniskstandard.Valid(), "Tekniskstandard", string(r.Tekniskstandard), "unknown tekniskstandard")
+	v.check(r.Tekstretning.Valid(), "Tekstretning", strconv.FormatFloat(float64(r.Tekstretning), 'f', -1, 64), "must be 0-400")
+	return v.err()
+}
+
+// Validate checks that the kommunekode and vejkode are 1-9999, and the length of the names.
+// A ValidationError with all violations is returned.
+func (r ReplikeringVejstykke) Validate() error {
+	var v validator
+	v.intRange("Kommunekode", r.Kommunekode, 1, 9999)
+	v.intRange("Kode", r.Kode, 1, 9999)
+	v.maxLen("Navn", r.Navn, 40)
+	v.maxLen("Adresseringsnavn", r.Adresseringsnavn, 20)
+	return v.err()
+}
+
+// Validate checks that Nr is 1-9999 and Navn is at most 20 characters.
+// A ValidationError with all violations is returned.
+func (r ReplikeringPostnummer) Validate() error {
+	var v validator
+	v.intRange("Nr", r.Nr, 1, 9999)
+	v.maxLen("Navn", r.Navn, 20)
+	return v.err()
+}
+
+// Validate checks the replicated object of the hændelse.
+// A ValidationError with all violations is returned.
+func (h Hændelse) Validate() error {
+	var v validator
+	switch {
+	case h.Adresse != nil:
+		v.nested("Adresse", h.Adresse.Validate())
+	case h.AdgangsAdresse != nil:
+		v.nested("AdgangsAdresse", h.AdgangsAdresse.Validate())
+	case h.Vejstykke != nil:
+		v.nested("Vejstykke", h.Vejstykke.Validate())
+	case h.Postnummer != nil:
+		v.nested("Postnummer", h.Postnummer.Validate())
+	default:
+		v.add("Entity", string(h.Entity), "no object")
+	}
+	return v.err()
+}
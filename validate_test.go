@@ -0,0 +1,276 @@
+package dawa
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestValidateImported(t *testing.T) {
+	aa, err := ImportAdgangsAdresserJSON(bytes.NewBufferString(adgangs_json_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ad, err := ImportAdresserJSON(bytes.NewBufferString(json_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pn, err := ImportPostnumreJSON(bytes.NewBufferString(postnumre_json_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs, err := ImportVejstykkerJSON(bytes.NewBufferString(vejstykker_json_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb, err := ImportSupplBynavnJSON(bytes.NewBufferString(suppl_bynavn_json_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	by, err := ImportBygningerJSON(bytes.NewBufferString(bygninger_json_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	js, err := ImportJordstykkerJSON(bytes.NewBufferString(jordstykker_json_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sn, err := ImportStednavneJSON(bytes.NewBufferString(stednavne_json_input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The test data is valid, so nothing should be sent to the sink.
+	n := 0
+	sink := func(r interface{}, err error) { t.Errorf("unexpected invalid record %#v: %v", r, err) }
+	next := map[string]func() (interface{}, error){
+		"adgangsadresser": func() (interface{}, error) { return aa.Next() },
+		"adresser":        func() (interface{}, error) { return ad.Next() },
+		"postnumre":       func() (interface{}, error) { return pn.Next() },
+		"vejstykker":      func() (interface{}, error) { return vs.Next() },
+		"supplbynavne":    func() (interface{}, error) { return sb.Next() },
+		"bygninger":       func() (interface{}, error) { return by.Next() },
+		"jordstykker":     func() (interface{}, error) { return js.Next() },
+		"stednavne":       func() (interface{}, error) { return sn.Next() },
+	}
+	aa.ValidateTo(sink)
+	ad.ValidateTo(sink)
+	pn.ValidateTo(sink)
+	vs.ValidateTo(sink)
+	sb.ValidateTo(sink)
+	by.ValidateTo(sink)
+	js.ValidateTo(sink)
+	sn.ValidateTo(sink)
+	for name, fn := range next {
+		for {
+			_, err := fn()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			n++
+		}
+	}
+	if n == 0 {
+		t.Fatal("no records imported")
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	var a Adresse
+	a.ID = "not-a-uuid"
+	a.Etage = "100"
+	a.Adgangsadresse.ID = "0a3f5081-c0b4-32b8-e044-0003ba298018"
+	a.Adgangsadresse.Kommune.Kode = "101"
+	a.Adgangsadresse.Vejstykke.Kode = "6100"
+	a.Adgangsadresse.Postnummer.Nr = "24000"
+	a.Adgangsadresse.Husnr = "46"
+	a.Adgangsadresse.EsrEjendomsNr = "12345678"
+
+	err := a.Validate()
+	ve, ok := err.(ValidationError)
+	if !ok {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	want := []string{"ID", "Adgangsadresse.Kommune.Kode", "Adgangsadresse.Postnummer.Nr", "Adgangsadresse.EsrEjendomsNr", "Etage"}
+	if len(ve) != len(want) {
+		t.Fatalf("expected %d violations, got %v", len(want), ve)
+	}
+	for i, f := range ve {
+		if f.Field != want[i] {
+			t.Errorf("violation %d: got field %q, want %q", i, f.Field, want[i])
+		}
+	}
+	if err := (PostnummerRef{Nr: "2400"}).Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := (Vejstykke{Kode: "61", Kommune: KommuneRef{Kode: "0101"}}).Validate(); err == nil {
+		t.Fatal("expected error on vejkode")
+	}
+}
+
+func TestValidateToSink(t *testing.T) {
+	iter, err := ImportPostnumreJSON(bytes.NewBufferString(`[{"nr":"2400","navn":"København NV"},{"nr":"24","navn":"Fejl"},{"nr":"8000","navn":"Aarhus C"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var invalid []*Postnummer
+	iter.ValidateTo(func(r interface{}, err error) {
+		if _, ok := err.(ValidationError); !ok {
+			t.Errorf("unexpected error type %T", err)
+		}
+		invalid = append(invalid, r.(*Postnummer))
+	})
+	var valid []string
+	for {
+		p, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid = append(valid, p.Nr)
+	}
+	if len(valid) != 2 || valid[0] != "2400" || valid[1] != "8000" {
+		t.Fatalf("unexpected valid records %v", valid)
+	}
+	if len(invalid) != 1 || invalid[0].Nr != "24" {
+		t.Fatalf("unexpected invalid records %v", invalid)
+	}
+}
+
+func TestValidateToSinkJSONCodes(t *testing.T) {
+	// Unknown codes in JSON input must reach the sink, and not abort the import.
+	input := `[{"id":"0a3f50a0-75eb-32b8-e044-0003ba298018","status":7,"adgangsadresse":{"id":"0a3f5081-c0b4-32b8-e044-0003ba298018","kommune":{"kode":"0101"},"vejstykke":{"kode":"6100"},"postnummer":{"nr":"2400"},"husnr":"46"}},
+	{"id":"0a3f50a1-75eb-32b8-e044-0003ba298018","status":1,"adgangsadresse":{"id":"0a3f5081-c0b4-32b8-e044-0003ba298018","kommune":{"kode":"0101"},"vejstykke":{"kode":"6100"},"postnummer":{"nr":"2400"},"husnr":"46"}}]`
+	iter, err := ImportAdresserJSON(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var invalid []*Adresse
+	iter.ValidateTo(func(r interface{}, err error) {
+		ve, ok := err.(ValidationError)
+		if !ok || len(ve) != 1 || ve[0].Field != "Status" {
+			t.Errorf("unexpected error %v", err)
+		}
+		invalid = append(invalid, r.(*Adresse))
+	})
+	var valid []string
+	for {
+		a, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid = append(valid, a.ID)
+	}
+	if len(valid) != 1 || valid[0] != "0a3f50a1-75eb-32b8-e044-0003ba298018" {
+		t.Fatalf("unexpected valid records %v", valid)
+	}
+	if len(invalid) != 1 || invalid[0].Status != 7 {
+		t.Fatalf("unexpected invalid records %v", invalid)
+	}
+}
+
+func TestValidateAreas(t *testing.T) {
+	valid := []interface {
+		Validate() error
+	}{
+		Region{RegionRef: RegionRef{Kode: "1084"}},
+		Sogn{SognRef: SognRef{Kode: "9185"}},
+		Politikreds{PolitikredsRef: PolitikredsRef{Kode: "1470"}},
+		Retskreds{RetskredsRef: RetskredsRef{Kode: "1101"}},
+		Opstillingskreds{OpstillingskredsRef: OpstillingskredsRef{Kode: "0009"}},
+		Valglandsdel{ValglandsdelRef: ValglandsdelRef{Bogstav: "A"}},
+		Storkreds{StorkredsRef: StorkredsRef{Nummer: "1"}, Regionskode: "1084", Valglandsdel: ValglandsdelRef{Bogstav: "A"}},
+		Landsdel{LandsdelRef: LandsdelRef{Nuts3: "DK011"}},
+		Afstemningsområde{AfstemningsområdeRef: AfstemningsområdeRef{Nummer: "8"}, Kommune: KommuneRef{Kode: "0101"}},
+		Menighedsrådsafstemningsområde{MenighedsrådsafstemningsområdeRef: MenighedsrådsafstemningsområdeRef{Nummer: "4"}, Sogn: SognRef{Kode: "9185"}},
+		Ejerlav{Kode: 2000174},
+	}
+	for _, v := range valid {
+		if err := v.Validate(); err != nil {
+			t.Errorf("%#v: %v", v, err)
+		}
+	}
+	invalid := []interface {
+		Validate() error
+	}{
+		Region{RegionRef: RegionRef{Kode: "84"}},
+		SognRef{},
+		Opstillingskreds{OpstillingskredsRef: OpstillingskredsRef{Kode: "A9"}},
+		ValglandsdelRef{Bogstav: "AB"},
+		Storkreds{StorkredsRef: StorkredsRef{Nummer: "1"}, Valglandsdel: ValglandsdelRef{Bogstav: "1"}},
+		LandsdelRef{Nuts3: "SE011"},
+		Afstemningsområde{AfstemningsområdeRef: AfstemningsområdeRef{Nummer: "8"}, Kommune: KommuneRef{Kode: "101"}},
+		MenighedsrådsafstemningsområdeRef{Nummer: "1000"},
+		Ejerlav{},
+	}
+	for _, v := range invalid {
+		if err := v.Validate(); err == nil {
+			t.Errorf("%#v: expected error", v)
+		}
+	}
+
+	// All list types can be validated.
+	for _, lt := range listTypes {
+		if _, ok := NewListQuery(lt, false).Type().(interface {
+			Validate() error
+		}); !ok {
+			t.Errorf("%s: no Validate method", lt)
+		}
+	}
+}
+
+func TestValidateHændelser(t *testing.T) {
+	input := `[{"operation":"update","tidspunkt":"2014-05-05T19:07:48.577Z","sekvensnummer":3,
+	"data":{"kommunekode":550,"kode":1,"navn":"A Hansensvej","adresseringsnavn":"A Hansensvej"}},
+	{"operation":"update","tidspunkt":"2014-05-05T19:07:48.577Z","sekvensnummer":4,
+	"data":{"kommunekode":550,"kode":0,"navn":"Fejl","adresseringsnavn":"Fejl"}}]`
+	iter, err := ImportHændelserJSON(EntityVejstykke, bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var invalid []int64
+	iter.ValidateTo(func(r interface{}, err error) {
+		invalid = append(invalid, r.(*Hændelse).Sekvensnummer)
+	})
+	var valid []int64
+	for {
+		h, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid = append(valid, h.Sekvensnummer)
+	}
+	if len(valid) != 1 || valid[0] != 3 || len(invalid) != 1 || invalid[0] != 4 {
+		t.Fatalf("unexpected result: valid %v, invalid %v", valid, invalid)
+	}
+	r := ReplikeringAdgangsAdresse{ID: "0a3f507a-3669-32b8-e044-0003ba298018", Kommunekode: 101, Vejkode: 4, Husnr: "3A", Postnr: 1654, Status: 1}
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	r.Husnr = "0"
+	if err := r.Validate(); err == nil {
+		t.Fatal("expected error on husnr")
+	}
+	if err := (ReplikeringPostnummer{Nr: 2400, Navn: "København NV"}).Validate(); err != nil {
+		t.Fatal(err)
+	}
+	ra := ReplikeringAdresse{ID: "0a3f50b7-6545-32b8-e044-0003ba298018", AdgangsadresseID: "0a3f508c-3307-32b8-e044-0003ba298018", Kilde: KildeMatrikel}
+	if err := ra.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	ra.Kilde = 9
+	if err := ra.Validate(); err == nil {
+		t.Fatal("expected error on kilde")
+	}
+}
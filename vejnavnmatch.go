@@ -0,0 +1,303 @@
+package dawa
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// VejnavnMatcher finds vejnavne matching user input that may be abbreviated,
+// misspelled or typed without the Danish letters æ, ø and å.
+//
+// For example "Gl. Kongevej", "gl kongevej" and "Gammel Kongevej" all match "Gammel Kongevej",
+// and "Rodkildevej" matches "Rødkildevej".
+//
+// Names are normalized by expanding common abbreviations, like "Gl." and "Ndr.",
+// and by folding æ, ø and å to ae, oe and aa. Candidates are then scored by
+// edit distance and phonetic similarity of the normalized names.
+//
+// The matcher can be used offline, or to correct input before querying DAWA:
+//			m := dawa.NewVejnavnMatcher()
+//			err := m.LoadVejstykker(iter)
+//			q := dawa.NewAdgangsAdresseQuery().VejnavnMatch(m, "gl kongevej").Husnr("10")
+//
+// The matcher is not safe for concurrent modification, but
+// matching can be done concurrently when it is no longer modified.
+type VejnavnMatcher struct {
+	MinScore float64 // The minimum score of returned matches. Default is 0.6.
+
+	entries []vejnavnEntry
+	known   map[string]bool
+}
+
+// VejnavnMatch is a vejnavn found by a VejnavnMatcher.
+type VejnavnMatch struct {
+	Navn  string  // The vejnavn as registered.
+	Score float64 // Score between 0 and 1. 1 is a perfect match after normalization.
+}
+
+type vejnavnEntry struct {
+	navn     string // Name to return.
+	norm     string // Normalized name.
+	phonetic string // Phonetic key of the normalized name.
+}
+
+// NewVejnavnMatcher returns a new, empty VejnavnMatcher.
+func NewVejnavnMatcher() *VejnavnMatcher {
+	return &VejnavnMatcher{MinScore: 0.6, known: make(map[string]bool)}
+}
+
+// LoadVejstykker will add the names of all vejstykker from the iterator.
+// The iterator is read until io.EOF is returned.
+func (m *VejnavnMatcher) LoadVejstykker(iter *VejstykkeIter) error {
+	for {
+		v, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		m.AddVejstykke(v)
+	}
+}
+
+// AddVejstykke will add the Navn of the vejstykke.
+// The Adresseringsnavn is also added, so it matches the full Navn.
+func (m *VejnavnMatcher) AddVejstykke(v *Vejstykke) {
+	m.add(v.Navn, v.Navn)
+	if v.Adresseringsnavn != "" {
+		m.add(v.Adresseringsnavn, v.Navn)
+	}
+}
+
+// Add will add a vejnavn.
+func (m *VejnavnMatcher) Add(navn string) {
+	m.add(navn, navn)
+}
+
+func (m *VejnavnMatcher) add(s, navn string) {
+	if s == "" {
+		return
+	}
+	norm := NormalizeVejnavn(s)
+	key := norm + "\x00" + navn
+	if m.known[key] {
+		return
+	}
+	m.known[key] = true
+	m.entries = append(m.entries, vejnavnEntry{navn: navn, norm: norm, phonetic: vejnavnPhonetic(norm)})
+}
+
+// Match returns up to limit vejnavne matching s, best match first.
+// If limit is 0 or less, all matches are returned.
+// Only matches with a score of at least MinScore are returned.
+func (m *VejnavnMatcher) Match(s string, limit int) []VejnavnMatch {
+	norm := NormalizeVejnavn(s)
+	if norm == "" {
+		return nil
+	}
+	phon := vejnavnPhonetic(norm)
+	best := make(map[string]float64)
+	for _, e := range m.entries {
+		// Skip candidates that cannot reach the minimum score,
+		// since the edit distance is at least the difference in length.
+		d, mx := abs(len(e.norm)-len(norm)), maxInt(len(e.norm), len(norm))
+		if vejnavnEditWeight*(1-float64(d)/float64(mx))+vejnavnPhoneticWeight < m.MinScore {
+			continue
+		}
+		score := vejnavnScore(norm, phon, e)
+		if score >= m.MinScore && score > best[e.navn] {
+			best[e.navn] = score
+		}
+	}
+	res := make([]VejnavnMatch, 0, len(best))
+	for navn, score := range best {
+		res = append(res, VejnavnMatch{Navn: navn, Score: score})
+	}
+	sort.Sort(vejnavnByScore(res))
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res
+}
+
+// Best returns the best match for s.
+// If no vejnavn has a score of at least MinScore, false is returned.
+func (m *VejnavnMatcher) Best(s string) (VejnavnMatch, bool) {
+	res := m.Match(s, 1)
+	if len(res) == 0 {
+		return VejnavnMatch{}, false
+	}
+	return res[0], true
+}
+
+// VejnavnMatch will add a parameter for 'vejnavn' to the AdgangsAdresseQuery,
+// using the best match of s found by the matcher.
+// If no match is found, s is used unchanged.
+func (q *AdgangsAdresseQuery) VejnavnMatch(m *VejnavnMatcher, s string) *AdgangsAdresseQuery {
+	if b, ok := m.Best(s); ok {
+		s = b.Navn
+	}
+	return q.Vejnavn(s)
+}
+
+// vejnavnAbbreviations contains common abbreviations in Danish vejnavne,
+// and the word they are normalized to.
+var vejnavnAbbreviations = map[string]string{
+	"gl":    "gammel",
+	"gml":   "gammel",
+	"gamle": "gammel",
+	"ndr":   "nordre",
+	"sdr":   "soendre",
+	"oe":    "oester",
+	"oestr": "oestre",
+	"vestr": "vestre",
+	"st":    "store",
+	"ll":    "lille",
+	"skt":   "sankt",
+	"sct":   "sankt",
+	"kgs":   "kongens",
+	"chr":   "christian",
+	"fr":    "frederik",
+	"dr":    "dronning",
+	"pr":    "prins",
+	"pl":    "plads",
+	"boul":  "boulevard",
+}
+
+// NormalizeVejnavn returns a normalized form of a vejnavn, used for matching.
+//
+// The name is converted to lower case, æ, ø and å are folded to ae, oe and aa,
+// accents are removed, punctuation is removed and common abbreviations,
+// like "gl." and "ndr.", are expanded.
+func NormalizeVejnavn(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch r {
+		case 'æ', 'ä':
+			b.WriteString("ae")
+		case 'ø', 'ö':
+			b.WriteString("oe")
+		case 'å':
+			b.WriteString("aa")
+		case 'é', 'è', 'ê':
+			b.WriteRune('e')
+		case 'ü':
+			b.WriteRune('u')
+		default:
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				b.WriteRune(r)
+			} else {
+				b.WriteRune(' ')
+			}
+		}
+	}
+	words := strings.Fields(b.String())
+	for i, w := range words {
+		if v, ok := vejnavnAbbreviations[w]; ok {
+			words[i] = v
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+var vejnavnPhoneticReplacer = strings.NewReplacer(
+	"aa", "a", "ae", "e", "oe", "o",
+	"ch", "k", "ph", "f", "th", "t", "dt", "t",
+	"c", "k", "q", "k", "w", "v", "z", "s", "x", "ks", "y", "i",
+	" ", "",
+)
+
+// vejnavnPhonetic returns a phonetic key of a normalized vejnavn.
+// Letters that sound alike in Danish are mapped to the same letter,
+// and repeated letters and spaces are removed.
+func vejnavnPhonetic(norm string) string {
+	s := vejnavnPhoneticReplacer.Replace(norm)
+	var b strings.Builder
+	var last rune
+	for _, c := range s {
+		if c != last {
+			b.WriteRune(c)
+		}
+		last = c
+	}
+	return b.String()
+}
+
+// Weights of edit distance and phonetic similarity in the score.
+// They add up to less than 1, so only exact matches get a score of 1.
+const (
+	vejnavnEditWeight     = 0.6
+	vejnavnPhoneticWeight = 0.39
+)
+
+// vejnavnScore returns the similarity of the normalized input and the entry.
+func vejnavnScore(norm, phon string, e vejnavnEntry) float64 {
+	if norm == e.norm {
+		return 1
+	}
+	edit := 1 - float64(levenshtein(norm, e.norm))/float64(maxInt(len(norm), len(e.norm)))
+	phonetic := 0.0
+	if len(phon) > 0 && len(e.phonetic) > 0 {
+		phonetic = 1 - float64(levenshtein(phon, e.phonetic))/float64(maxInt(len(phon), len(e.phonetic)))
+	}
+	return vejnavnEditWeight*edit + vejnavnPhoneticWeight*phonetic
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	if a == b {
+		return 0
+	}
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+type vejnavnByScore []VejnavnMatch
+
+func (v vejnavnByScore) Len() int      { return len(v) }
+func (v vejnavnByScore) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
+func (v vejnavnByScore) Less(i, j int) bool {
+	if v[i].Score != v[j].Score {
+		return v[i].Score > v[j].Score
+	}
+	return v[i].Navn < v[j].Navn
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,70 @@
+package dawa
+
+import (
+	"testing"
+)
+
+func TestNormalizeVejnavn(t *testing.T) {
+	tests := map[string]string{
+		"Gl. Kongevej":        "gammel kongevej",
+		"gl kongevej":         "gammel kongevej",
+		"Gammel Kongevej":     "gammel kongevej",
+		"GL.KONGEVEJ":         "gammel kongevej",
+		"Rødkildevej":         "roedkildevej",
+		"Ndr. Frihavnsgade":   "nordre frihavnsgade",
+		"H.C. Ørsteds Vej":    "h c oersteds vej",
+		"Åboulevard":          "aaboulevard",
+		"  Skt.  Annæ Plads ": "sankt annae plads",
+	}
+	for in, expect := range tests {
+		if got := NormalizeVejnavn(in); got != expect {
+			t.Errorf("NormalizeVejnavn(%q): got %q, expected %q", in, got, expect)
+		}
+	}
+}
+
+func TestVejnavnMatcher(t *testing.T) {
+	m := NewVejnavnMatcher()
+	for _, n := range []string{"Gammel Kongevej", "Kongevejen", "Rødkildevej", "Rødovrevej", "Hvidkildevej", "Nordre Frihavnsgade"} {
+		m.Add(n)
+	}
+	m.AddVejstykke(&Vejstykke{Navn: "Christian den Niendes Gade", Adresseringsnavn: "Chr. IX's Gade"})
+
+	for in, expect := range map[string]string{
+		"Gl. Kongevej":     "Gammel Kongevej",
+		"gl kongevej":      "Gammel Kongevej",
+		"Gammel Kongevej":  "Gammel Kongevej",
+		"Rodkildevej":      "Rødkildevej",
+		"Roedkildevej":     "Rødkildevej",
+		"Rødkilevej":       "Rødkildevej",
+		"ndr frihavnsgade": "Nordre Frihavnsgade",
+		"Chr. IX's Gade":   "Christian den Niendes Gade",
+		"Hvidkildevej":     "Hvidkildevej",
+	} {
+		b, ok := m.Best(in)
+		if !ok || b.Navn != expect {
+			t.Errorf("Best(%q): got %#v, expected %q", in, b, expect)
+		}
+	}
+	if b, ok := m.Best("gammel kongevej"); !ok || b.Score != 1 {
+		t.Errorf("expected perfect score, got %#v", b)
+	}
+	if b, ok := m.Best("Rodkildevej"); !ok || b.Score >= 1 || b.Score < 0.9 {
+		t.Errorf("unexpected score: %#v", b)
+	}
+	if _, ok := m.Best("Zebrastien"); ok {
+		t.Error("expected no match")
+	}
+	res := m.Match("rødkildevej", 0)
+	if len(res) < 2 || res[0].Navn != "Rødkildevej" || res[0].Score < res[1].Score {
+		t.Errorf("unexpected matches: %#v", res)
+	}
+	if res := m.Match("rødkildevej", 1); len(res) != 1 {
+		t.Errorf("expected 1 match, got %d", len(res))
+	}
+
+	q := NewAdgangsAdresseQuery().VejnavnMatch(m, "gl kongevej")
+	if u := q.URL(); u != DefaultHost+"/adgangsadresser?vejnavn=Gammel+Kongevej" {
+		t.Errorf("unexpected URL: %s", u)
+	}
+}
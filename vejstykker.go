@@ -25,17 +25,30 @@ type Vejstykke struct {
 type VejstykkeIter struct {
 	a   chan Vejstykke
 	err error
+	validating
 }
 
 // Next will return addresses.
 // It will return an error if that has been encountered.
 // When there are not more entries nil, io.EOF will be returned.
 func (a *VejstykkeIter) Next() (*Vejstykke, error) {
-	v, ok := <-a.a
-	if ok {
-		return &v, nil
+	for {
+		v, ok := <-a.a
+		if !ok {
+			return nil, a.err
+		}
+		if !a.skip(&v) {
+			return &v, nil
+		}
 	}
-	return nil, a.err
+}
+
+// ValidateTo will make the iterator validate each record with its Validate method.
+// Invalid records are sent to the sink and skipped, instead of aborting the import.
+// It must be called before the first call to Next.
+func (a *VejstykkeIter) ValidateTo(sink ErrorSink) *VejstykkeIter {
+	a.sink = sink
+	return a
 }
 
 // ImportVejstykkerJSON will import "vejstykker" from a JSON input, supplied to the reader.